@@ -1,6 +1,10 @@
 package adapters
 
 import (
+	"fmt"
+	"sort"
+	"strings"
+
 	"github.com/linear-tui/linear-tui/internal/domain"
 	"github.com/linear-tui/linear-tui/internal/linear"
 	"time"
@@ -17,7 +21,7 @@ func NewLinearAdapter() *LinearAdapter {
 // ConvertIssueToUIModel converts a Linear Issue to a domain Issue for UI usage
 func (a *LinearAdapter) ConvertIssueToUIModel(issue linear.Issue) domain.Issue {
 	// Convert priority number to string
-	priorityStr := a.convertPriorityToString(issue.Priority)
+	priorityStr := a.ConvertPriorityToString(issue.Priority)
 
 	// Get assignee name or default
 	assigneeName := "Unassigned"
@@ -25,15 +29,60 @@ func (a *LinearAdapter) ConvertIssueToUIModel(issue linear.Issue) domain.Issue {
 		assigneeName = issue.Assignee.Name
 	}
 
+	var assigneeID string
+	if issue.Assignee != nil {
+		assigneeID = issue.Assignee.ID
+	}
+
+	var teamName, teamID, projectName, projectID string
+	if issue.Team != nil {
+		teamName, teamID = issue.Team.Name, issue.Team.ID
+	}
+	if issue.Project != nil {
+		projectName, projectID = issue.Project.Name, issue.Project.ID
+	}
+
+	var parent, parentID string
+	if issue.Parent != nil {
+		parent, parentID = issue.Parent.Identifier, issue.Parent.ID
+	}
+
+	var cycleName, cycleID string
+	var cycleNumber int
+	if issue.Cycle != nil {
+		cycleNumber = int(issue.Cycle.Number)
+		cycleName, cycleID = a.cycleName(*issue.Cycle), issue.Cycle.ID
+	}
+
+	labels := make([]domain.Label, len(issue.Labels.Nodes))
+	for i, label := range issue.Labels.Nodes {
+		labels[i] = domain.Label{ID: label.ID, Name: label.Name, Color: label.Color}
+	}
+
 	return domain.Issue{
-		ID:          issue.Identifier,
-		LinearID:    issue.ID,
-		Title:       issue.Title,
-		Description: issue.Description,
-		Status:      issue.State.Name,
-		Priority:    priorityStr,
-		Assignee:    assigneeName,
-		CreatedAt:   issue.CreatedAt,
+		ID:             issue.Identifier,
+		LinearID:       issue.ID,
+		Title:          issue.Title,
+		Description:    issue.Description,
+		Status:         issue.State.Name,
+		StateType:      issue.State.Type,
+		Priority:       priorityStr,
+		Assignee:       assigneeName,
+		Team:           teamName,
+		Project:        projectName,
+		Cycle:          cycleName,
+		Parent:         parent,
+		Labels:         labels,
+		CreatedAt:      issue.CreatedAt,
+		UpdatedAt:      issue.UpdatedAt,
+		TeamID:         teamID,
+		StateID:        issue.State.ID,
+		PriorityNumber: issue.Priority,
+		AssigneeID:     assigneeID,
+		ProjectID:      projectID,
+		CycleID:        cycleID,
+		CycleNumber:    cycleNumber,
+		ParentID:       parentID,
 	}
 }
 
@@ -54,6 +103,7 @@ func (a *LinearAdapter) ConvertProjectToUIModel(project linear.Project) domain.P
 		Description: project.Description,
 		Status:      project.State,
 		Progress:    project.Progress,
+		TargetDate:  parseProjectDate(project.TargetDate),
 		CreatedAt:   parseProjectDate(project.StartDate), // Use start date as created date
 	}
 }
@@ -67,8 +117,394 @@ func (a *LinearAdapter) ConvertProjectsToUIModels(projects []linear.Project) []d
 	return uiProjects
 }
 
-// convertPriorityToString converts Linear priority number to string
-func (a *LinearAdapter) convertPriorityToString(priority int) string {
+// ConvertProjectDetailsToUIModel converts a Linear project with its details
+// and the workflow states of its issues to domain ProjectDetails
+func (a *LinearAdapter) ConvertProjectDetailsToUIModel(project linear.ProjectDetails, states []linear.IssueState) domain.ProjectDetails {
+	details := domain.ProjectDetails{
+		Project:   a.ConvertProjectToUIModel(project.Project),
+		StartDate: parseProjectDate(project.StartDate),
+	}
+	if project.Lead != nil {
+		details.Lead = project.Lead.Name
+	}
+	for _, member := range project.Members.Nodes {
+		details.Members = append(details.Members, member.Name)
+	}
+	if project.Health != nil {
+		details.Health = map[string]string{
+			linear.HealthOnTrack:  "On track",
+			linear.HealthAtRisk:   "At risk",
+			linear.HealthOffTrack: "Off track",
+		}[*project.Health]
+	}
+	for _, milestone := range project.ProjectMilestones.Nodes {
+		details.Milestones = append(details.Milestones, domain.Milestone{
+			Name:       milestone.Name,
+			TargetDate: parseProjectDate(milestone.TargetDate),
+		})
+	}
+
+	index := make(map[string]int)
+	for _, state := range states {
+		i, ok := index[state.Name]
+		if !ok {
+			i = len(details.States)
+			index[state.Name] = i
+			details.States = append(details.States, domain.StateCount{Name: state.Name, Type: state.Type, Color: state.Color})
+		}
+		details.States[i].Count++
+	}
+	sort.SliceStable(details.States, func(i, j int) bool {
+		return linear.StateTypeOrder(details.States[i].Type) < linear.StateTypeOrder(details.States[j].Type)
+	})
+	return details
+}
+
+// ConvertIssueRelationsToUIModel converts the issues linked to an issue to
+// domain IssueRelations. Inverse relations are named from the issue's side,
+// so an inverse blocks relation becomes blocked by.
+func (a *LinearAdapter) ConvertIssueRelationsToUIModel(relations linear.IssueRelations) domain.IssueRelations {
+	var uiRelations domain.IssueRelations
+	if relations.Parent != nil {
+		parent := a.convertIssueLink(*relations.Parent)
+		uiRelations.Parent = &parent
+	}
+	for _, child := range relations.Children.Nodes {
+		uiRelations.Children = append(uiRelations.Children, a.convertIssueLink(child))
+	}
+
+	outgoing := map[string]string{
+		linear.RelationBlocks:    domain.RelationBlocks,
+		linear.RelationDuplicate: domain.RelationDuplicateOf,
+		linear.RelationRelated:   domain.RelationRelated,
+	}
+	for _, relation := range relations.Relations.Nodes {
+		if kind, ok := outgoing[relation.Type]; ok && relation.RelatedIssue != nil {
+			uiRelations.Relations = append(uiRelations.Relations, domain.Relation{Type: kind, Issue: a.convertIssueLink(*relation.RelatedIssue)})
+		}
+	}
+
+	incoming := map[string]string{
+		linear.RelationBlocks:    domain.RelationBlockedBy,
+		linear.RelationDuplicate: domain.RelationDuplicateBy,
+		linear.RelationRelated:   domain.RelationRelated,
+	}
+	for _, relation := range relations.InverseRelations.Nodes {
+		if kind, ok := incoming[relation.Type]; ok && relation.Issue != nil {
+			uiRelations.Relations = append(uiRelations.Relations, domain.Relation{Type: kind, Issue: a.convertIssueLink(*relation.Issue)})
+		}
+	}
+	return uiRelations
+}
+
+// ConvertRelationToInput converts a relation from issueID to relatedID to
+// the input for creating it in Linear. Linear only has relations in one
+// direction, so blocked by and duplicated by are created from the other
+// issue.
+func (a *LinearAdapter) ConvertRelationToInput(issueID, relatedID, relation string) (linear.CreateIssueRelationInput, error) {
+	input := linear.CreateIssueRelationInput{IssueID: issueID, RelatedIssueID: relatedID}
+	switch relation {
+	case domain.RelationBlocks:
+		input.Type = linear.RelationBlocks
+	case domain.RelationBlockedBy:
+		input = linear.CreateIssueRelationInput{IssueID: relatedID, RelatedIssueID: issueID, Type: linear.RelationBlocks}
+	case domain.RelationRelated:
+		input.Type = linear.RelationRelated
+	case domain.RelationDuplicateOf:
+		input.Type = linear.RelationDuplicate
+	case domain.RelationDuplicateBy:
+		input = linear.CreateIssueRelationInput{IssueID: relatedID, RelatedIssueID: issueID, Type: linear.RelationDuplicate}
+	default:
+		return input, fmt.Errorf("unknown relation %q", relation)
+	}
+	return input, nil
+}
+
+// convertIssueLink converts a Linear Issue to a reference to it
+func (a *LinearAdapter) convertIssueLink(issue linear.Issue) domain.IssueLink {
+	return domain.IssueLink{
+		ID:        issue.Identifier,
+		LinearID:  issue.ID,
+		Title:     issue.Title,
+		Status:    issue.State.Name,
+		StateType: issue.State.Type,
+	}
+}
+
+// ConvertIssueHistoryToUIModels converts the history of an issue to domain
+// HistoryEvents, one for each field changed by an entry. Changes to other
+// fields are left out.
+func (a *LinearAdapter) ConvertIssueHistoryToUIModels(history []linear.IssueHistory) []domain.HistoryEvent {
+	var events []domain.HistoryEvent
+	for _, entry := range history {
+		actor := "Linear"
+		if entry.Actor != nil {
+			actor = entry.Actor.Name
+		}
+		add := func(field, from, to string) {
+			events = append(events, domain.HistoryEvent{
+				ID:        entry.ID + ":" + field,
+				Actor:     actor,
+				Field:     field,
+				From:      from,
+				To:        to,
+				CreatedAt: entry.CreatedAt,
+			})
+		}
+
+		if entry.ToState != nil && (entry.FromState == nil || entry.FromState.ID != entry.ToState.ID) {
+			from := ""
+			if entry.FromState != nil {
+				from = entry.FromState.Name
+			}
+			add(domain.HistoryStatus, from, entry.ToState.Name)
+		}
+		if entry.FromAssignee != nil || entry.ToAssignee != nil {
+			var from, to string
+			if entry.FromAssignee != nil {
+				from = entry.FromAssignee.Name
+			}
+			if entry.ToAssignee != nil {
+				to = entry.ToAssignee.Name
+			}
+			add(domain.HistoryAssignee, from, to)
+		}
+		if entry.FromPriority != nil && entry.ToPriority != nil && *entry.FromPriority != *entry.ToPriority {
+			add(domain.HistoryPriority, a.ConvertPriorityToString(int(*entry.FromPriority)), a.ConvertPriorityToString(int(*entry.ToPriority)))
+		}
+		if len(entry.AddedLabels) > 0 || len(entry.RemovedLabels) > 0 {
+			add(domain.HistoryLabels, labelNames(entry.RemovedLabels), labelNames(entry.AddedLabels))
+		}
+	}
+	return events
+}
+
+// labelNames lists the names of labels, comma separated
+func labelNames(labels []linear.IssueLabel) string {
+	names := make([]string, len(labels))
+	for i, label := range labels {
+		names[i] = label.Name
+	}
+	return strings.Join(names, ", ")
+}
+
+// ConvertCycleToUIModel converts a Linear Cycle to a domain Cycle
+func (a *LinearAdapter) ConvertCycleToUIModel(cycle linear.Cycle) domain.Cycle {
+	status := "Past"
+	switch {
+	case cycle.IsActive:
+		status = "Current"
+	case cycle.IsFuture:
+		status = "Upcoming"
+	}
+
+	var teamName, teamID string
+	if cycle.Team != nil {
+		teamName, teamID = cycle.Team.Name, cycle.Team.ID
+	}
+
+	return domain.Cycle{
+		ID:        cycle.ID,
+		Number:    int(cycle.Number),
+		Name:      a.cycleName(cycle),
+		Team:      teamName,
+		TeamID:    teamID,
+		Status:    status,
+		StartsAt:  cycle.StartsAt,
+		EndsAt:    cycle.EndsAt,
+		Scope:     lastCount(cycle.IssueCountHistory),
+		Completed: lastCount(cycle.CompletedIssueCountHistory),
+		Progress:  cycle.Progress,
+	}
+}
+
+// ConvertCyclesToUIModels converts a slice of Linear Cycles to domain Cycles
+func (a *LinearAdapter) ConvertCyclesToUIModels(cycles []linear.Cycle) []domain.Cycle {
+	uiCycles := make([]domain.Cycle, len(cycles))
+	for i, cycle := range cycles {
+		uiCycles[i] = a.ConvertCycleToUIModel(cycle)
+	}
+	return uiCycles
+}
+
+// cycleName returns the name of a cycle, or its number for cycles without
+// a name
+func (a *LinearAdapter) cycleName(cycle linear.Cycle) string {
+	if cycle.Name != "" {
+		return cycle.Name
+	}
+	return fmt.Sprintf("Cycle %d", int(cycle.Number))
+}
+
+// lastCount returns the current value of a cycle's count history
+func lastCount(history []float64) int {
+	if len(history) == 0 {
+		return 0
+	}
+	return int(history[len(history)-1])
+}
+
+// ConvertCommentToUIModel converts a Linear Comment to a domain Comment
+func (a *LinearAdapter) ConvertCommentToUIModel(comment linear.Comment) domain.Comment {
+	// Comments by integrations have no user
+	authorName := "Unknown"
+	if comment.User != nil {
+		authorName = comment.User.Name
+	}
+
+	var parentID string
+	if comment.Parent != nil {
+		parentID = comment.Parent.ID
+	}
+
+	return domain.Comment{
+		ID:        comment.ID,
+		Body:      comment.Body,
+		Author:    authorName,
+		ParentID:  parentID,
+		CreatedAt: comment.CreatedAt,
+		UpdatedAt: comment.UpdatedAt,
+	}
+}
+
+// ConvertCommentsToUIModels converts a slice of Linear Comments to domain Comments
+func (a *LinearAdapter) ConvertCommentsToUIModels(comments []linear.Comment) []domain.Comment {
+	uiComments := make([]domain.Comment, len(comments))
+	for i, comment := range comments {
+		uiComments[i] = a.ConvertCommentToUIModel(comment)
+	}
+	return uiComments
+}
+
+// notificationReasons describes the types of notification
+var notificationReasons = map[string]string{
+	"issueAssignedToYou":     "Assigned to you",
+	"issueUnassignedFromYou": "Unassigned from you",
+	"issueCreated":           "New issue",
+	"issueNewComment":        "New comment",
+	"issueCommentMention":    "Mentioned you",
+	"issueMention":           "Mentioned you",
+	"issueCommentReaction":   "Reacted to your comment",
+	"issueEmojiReaction":     "Reacted to your issue",
+	"issueStatusChanged":     "Status changed",
+	"issueStatusChangedAll":  "Status changed",
+	"issuePriorityUrgent":    "Marked urgent",
+	"issueDue":               "Due",
+	"issueSubscribed":        "Subscribed you",
+	"issueBlocking":          "Blocking changed",
+	"issueReminder":          "Reminder",
+}
+
+// ConvertNotificationToUIModel converts a Linear Notification to a domain
+// Notification
+func (a *LinearAdapter) ConvertNotificationToUIModel(notification linear.Notification) domain.Notification {
+	reason, ok := notificationReasons[notification.Type]
+	if !ok {
+		reason = notification.Type
+	}
+
+	// Notifications sent by Linear itself have no actor
+	actorName := "Linear"
+	if notification.Actor != nil {
+		actorName = notification.Actor.Name
+	}
+
+	uiNotification := domain.Notification{
+		ID:        notification.ID,
+		Reason:    reason,
+		Actor:     actorName,
+		Read:      notification.ReadAt != nil,
+		CreatedAt: notification.CreatedAt,
+	}
+	if notification.Issue != nil {
+		uiNotification.IssueID = notification.Issue.ID
+		uiNotification.IssueIdentifier = notification.Issue.Identifier
+		uiNotification.IssueTitle = notification.Issue.Title
+	}
+	if notification.SnoozedUntilAt != nil {
+		uiNotification.SnoozedUntil = *notification.SnoozedUntilAt
+	}
+	return uiNotification
+}
+
+// ConvertNotificationsToUIModels converts a slice of Linear Notifications to
+// domain Notifications
+func (a *LinearAdapter) ConvertNotificationsToUIModels(notifications []linear.Notification) []domain.Notification {
+	uiNotifications := make([]domain.Notification, len(notifications))
+	for i, notification := range notifications {
+		uiNotifications[i] = a.ConvertNotificationToUIModel(notification)
+	}
+	return uiNotifications
+}
+
+// ConvertNewIssueToInput converts a domain NewIssue to the input for
+// creating it in Linear. Empty fields are left unset, so Linear's defaults
+// apply to them.
+func (a *LinearAdapter) ConvertNewIssueToInput(issue domain.NewIssue) linear.CreateIssueInput {
+	input := linear.CreateIssueInput{
+		Title:       issue.Title,
+		TeamID:      issue.TeamID,
+		Description: optionalString(issue.Description),
+		AssigneeID:  optionalString(issue.AssigneeID),
+		ProjectID:   optionalString(issue.ProjectID),
+		StateID:     optionalString(issue.StateID),
+		ParentID:    optionalString(issue.ParentID),
+	}
+	if issue.Priority != 0 {
+		input.Priority = linear.Set(issue.Priority)
+	}
+	if len(issue.LabelIDs) > 0 {
+		input.LabelIDs = linear.Set(issue.LabelIDs)
+	}
+	return input
+}
+
+// ConvertIssueUpdateToInput converts a domain IssueUpdate to the input for
+// updating the issue in Linear. Empty IDs become nulls, which clear the
+// field.
+func (a *LinearAdapter) ConvertIssueUpdateToInput(update domain.IssueUpdate) linear.UpdateIssueInput {
+	var input linear.UpdateIssueInput
+	if update.Title != nil {
+		input.Title = linear.Set(*update.Title)
+	}
+	if update.Description != nil {
+		input.Description = linear.Set(*update.Description)
+	}
+	if update.Priority != nil {
+		input.Priority = linear.Set(*update.Priority)
+	}
+	input.StateID = optionalID(update.StateID)
+	input.AssigneeID = optionalID(update.AssigneeID)
+	input.ProjectID = optionalID(update.ProjectID)
+	input.CycleID = optionalID(update.CycleID)
+	if update.LabelIDs != nil {
+		input.LabelIDs = linear.Set(update.LabelIDs)
+	}
+	return input
+}
+
+// optionalString returns an Optional set to value, or unset if it is empty
+func optionalString(value string) linear.Optional[string] {
+	if value == "" {
+		return linear.Optional[string]{}
+	}
+	return linear.Set(value)
+}
+
+// optionalID returns an Optional for an ID that is unset if id is nil and
+// null if it is empty
+func optionalID(id *string) linear.Optional[string] {
+	switch {
+	case id == nil:
+		return linear.Optional[string]{}
+	case *id == "":
+		return linear.Null[string]()
+	}
+	return linear.Set(*id)
+}
+
+// ConvertPriorityToString converts Linear priority number to string
+func (a *LinearAdapter) ConvertPriorityToString(priority int) string {
 	switch priority {
 	case 0:
 		return "None"
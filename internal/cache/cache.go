@@ -0,0 +1,363 @@
+// Package cache keeps a copy of the Linear workspace on disk between runs:
+// the authenticated user, teams, users, workflow states, issues and
+// projects. The copy is brought up to date by fetching only what changed
+// since its watermarks, the latest update times of the issues and of the
+// projects it holds. Edits made while Linear cannot be reached wait in an
+// Outbox kept alongside it.
+package cache
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/linear-tui/linear-tui/internal/linear"
+)
+
+// version is the version of the file format. Files of other versions are
+// ignored, so that the cache is refilled from scratch after a change.
+const version = 2
+
+// snapshot is the contents of the cache file
+type snapshot struct {
+	Version           int                            `json:"version"`
+	IssuesWatermark   time.Time                      `json:"issuesWatermark"`
+	IssuesComplete    bool                           `json:"issuesComplete"`
+	ProjectsWatermark time.Time                      `json:"projectsWatermark"`
+	Viewer            *linear.User                   `json:"viewer"`
+	Teams             []linear.Team                  `json:"teams"`
+	Users             []linear.User                  `json:"users"`
+	States            map[string][]linear.IssueState `json:"states"`
+	Issues            map[string]linear.Issue        `json:"issues"`
+	Projects          map[string]linear.Project      `json:"projects"`
+}
+
+// Cache is the on-disk copy of a workspace. It is safe for concurrent use.
+type Cache struct {
+	path string
+
+	mu   sync.Mutex
+	data snapshot
+}
+
+// Path returns the path of the cache file of the workspace an API key
+// belongs to, under the user's cache directory ($XDG_CACHE_HOME on Linux).
+// The file is named after a hash of the key, so workspaces are kept apart
+// without storing the key.
+func Path(apiKey string) (string, error) {
+	return workspacePath(apiKey, ".json")
+}
+
+// OutboxPath returns the path of the outbox file of the workspace an API
+// key belongs to, next to its cache file
+func OutboxPath(apiKey string) (string, error) {
+	return workspacePath(apiKey, ".outbox.json")
+}
+
+// workspacePath returns the path of a file of the workspace an API key
+// belongs to under the user's cache directory
+func workspacePath(apiKey, suffix string) (string, error) {
+	cacheDir, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+
+	sum := sha256.Sum256([]byte(apiKey))
+	return filepath.Join(cacheDir, "linear-tui", hex.EncodeToString(sum[:8])+suffix), nil
+}
+
+// Open reads the cache file at path. A missing or unreadable file gives an
+// empty cache, as does an empty path, in which case the cache is only kept
+// in memory.
+func Open(path string) *Cache {
+	c := &Cache{path: path}
+	c.reset()
+	if path == "" {
+		return c
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return c
+	}
+	var loaded snapshot
+	if err := json.Unmarshal(data, &loaded); err != nil || loaded.Version != version {
+		return c
+	}
+	if loaded.States == nil {
+		loaded.States = make(map[string][]linear.IssueState)
+	}
+	if loaded.Issues == nil {
+		loaded.Issues = make(map[string]linear.Issue)
+	}
+	if loaded.Projects == nil {
+		loaded.Projects = make(map[string]linear.Project)
+	}
+	c.data = loaded
+	return c
+}
+
+// reset empties the cache
+func (c *Cache) reset() {
+	c.data = snapshot{
+		Version:  version,
+		States:   make(map[string][]linear.IssueState),
+		Issues:   make(map[string]linear.Issue),
+		Projects: make(map[string]linear.Project),
+	}
+}
+
+// Save writes the cache to its file. The file is replaced at once, so a
+// crash while saving leaves the previous copy in place. It is readable
+// only by the user, since it holds the contents of their workspace.
+func (c *Cache) Save() error {
+	if c.path == "" {
+		return nil
+	}
+
+	c.mu.Lock()
+	data, err := json.Marshal(c.data)
+	c.mu.Unlock()
+	if err != nil {
+		return err
+	}
+	return writeFile(c.path, data)
+}
+
+// writeFile replaces the file at path with data at once, creating its
+// directory if needed. The file is readable only by the user.
+func writeFile(path string, data []byte) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// Workspace returns the authenticated user, teams and users. ok is false
+// if they have not been cached.
+func (c *Cache) Workspace() (viewer *linear.User, teams []linear.Team, users []linear.User, ok bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.data.Viewer == nil || len(c.data.Teams) == 0 {
+		return nil, nil, nil, false
+	}
+	return c.data.Viewer, c.data.Teams, c.data.Users, true
+}
+
+// SetWorkspace caches the authenticated user, teams and users. The states
+// and issues of teams that are gone are dropped.
+func (c *Cache) SetWorkspace(viewer *linear.User, teams []linear.Team, users []linear.User) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.data.Viewer, c.data.Teams, c.data.Users = viewer, teams, users
+
+	known := make(map[string]bool, len(teams))
+	for _, team := range teams {
+		known[team.ID] = true
+	}
+	for teamID := range c.data.States {
+		if !known[teamID] {
+			delete(c.data.States, teamID)
+		}
+	}
+	for id, issue := range c.data.Issues {
+		if issue.Team != nil && !known[issue.Team.ID] {
+			delete(c.data.Issues, id)
+		}
+	}
+}
+
+// States returns the workflow states of a team. ok is false if they have
+// not been cached.
+func (c *Cache) States(teamID string) (states []linear.IssueState, ok bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	states, ok = c.data.States[teamID]
+	return states, ok
+}
+
+// SetStates caches the workflow states of a team
+func (c *Cache) SetStates(teamID string, states []linear.IssueState) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.data.States[teamID] = states
+}
+
+// StateTeamIDs returns the IDs of the teams whose states are cached
+func (c *Cache) StateTeamIDs() []string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	ids := make([]string, 0, len(c.data.States))
+	for teamID := range c.data.States {
+		ids = append(ids, teamID)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// Watermarks returns the latest update times of the issues and of the
+// projects synced. Each is the zero time until its first sync.
+func (c *Cache) Watermarks() (issues, projects time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.data.IssuesWatermark, c.data.ProjectsWatermark
+}
+
+// IssuesComplete reports whether the cache holds every open issue: the
+// first sync of the issues fetched them all, rather than stopping at its
+// limit
+func (c *Cache) IssuesComplete() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.data.IssuesComplete
+}
+
+// SetIssuesComplete records whether the cache holds every open issue
+func (c *Cache) SetIssuesComplete(complete bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.data.IssuesComplete = complete
+}
+
+// ResetIssues drops the cached issues and their watermark, so that they
+// are synced from scratch
+func (c *Cache) ResetIssues() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.data.IssuesWatermark = time.Time{}
+	c.data.IssuesComplete = false
+	c.data.Issues = make(map[string]linear.Issue)
+}
+
+// Issues returns the cached issues, most recently updated first
+func (c *Cache) Issues() []linear.Issue {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	issues := make([]linear.Issue, 0, len(c.data.Issues))
+	for _, issue := range c.data.Issues {
+		issues = append(issues, issue)
+	}
+	sort.Slice(issues, func(i, j int) bool {
+		if !issues[i].UpdatedAt.Equal(issues[j].UpdatedAt) {
+			return issues[i].UpdatedAt.After(issues[j].UpdatedAt)
+		}
+		return issues[i].ID < issues[j].ID
+	})
+	return issues
+}
+
+// Issue returns a cached issue
+func (c *Cache) Issue(id string) (linear.Issue, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	issue, ok := c.data.Issues[id]
+	return issue, ok
+}
+
+// PutIssues caches issues, keeping the cached copy of any that is newer
+func (c *Cache) PutIssues(issues ...linear.Issue) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, issue := range issues {
+		if cached, ok := c.data.Issues[issue.ID]; ok && cached.UpdatedAt.After(issue.UpdatedAt) {
+			continue
+		}
+		c.data.Issues[issue.ID] = issue
+	}
+}
+
+// RemoveIssues drops issues from the cache
+func (c *Cache) RemoveIssues(ids ...string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, id := range ids {
+		delete(c.data.Issues, id)
+	}
+}
+
+// ApplyIssues caches issues synced, dropping those archived or deleted,
+// and advances the issues watermark to the latest update time among them.
+// A sync that brought no issues leaves the watermark where it was.
+func (c *Cache) ApplyIssues(issues []linear.SyncedIssue) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, issue := range issues {
+		if issue.ArchivedAt != nil {
+			delete(c.data.Issues, issue.ID)
+		} else if cached, ok := c.data.Issues[issue.ID]; !ok || !cached.UpdatedAt.After(issue.UpdatedAt) {
+			c.data.Issues[issue.ID] = issue.Issue
+		}
+		if issue.UpdatedAt.After(c.data.IssuesWatermark) {
+			c.data.IssuesWatermark = issue.UpdatedAt
+		}
+	}
+}
+
+// Projects returns the cached projects, most recently updated first
+func (c *Cache) Projects() []linear.Project {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	projects := make([]linear.Project, 0, len(c.data.Projects))
+	for _, project := range c.data.Projects {
+		projects = append(projects, project)
+	}
+	sort.Slice(projects, func(i, j int) bool {
+		if !projects[i].UpdatedAt.Equal(projects[j].UpdatedAt) {
+			return projects[i].UpdatedAt.After(projects[j].UpdatedAt)
+		}
+		return projects[i].ID < projects[j].ID
+	})
+	return projects
+}
+
+// ApplyProjects caches projects synced, dropping those archived or
+// deleted, and advances the projects watermark to the latest update time
+// among them
+func (c *Cache) ApplyProjects(projects []linear.SyncedProject) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, project := range projects {
+		if project.ArchivedAt != nil {
+			delete(c.data.Projects, project.ID)
+		} else if cached, ok := c.data.Projects[project.ID]; !ok || !cached.UpdatedAt.After(project.UpdatedAt) {
+			c.data.Projects[project.ID] = project.Project
+		}
+		if project.UpdatedAt.After(c.data.ProjectsWatermark) {
+			c.data.ProjectsWatermark = project.UpdatedAt
+		}
+	}
+}
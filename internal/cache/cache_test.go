@@ -0,0 +1,147 @@
+package cache
+
+import (
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/linear-tui/linear-tui/internal/linear"
+)
+
+func TestApplyIssues(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2024, 1, d, 0, 0, 0, 0, time.UTC) }
+	issue := func(id string, updated int) linear.Issue {
+		return linear.Issue{ID: id, Title: id, UpdatedAt: day(updated)}
+	}
+	synced := func(id string, updated int) linear.SyncedIssue {
+		return linear.SyncedIssue{Issue: issue(id, updated)}
+	}
+	archived := func(id string, updated int) linear.SyncedIssue {
+		at := day(updated)
+		return linear.SyncedIssue{Issue: issue(id, updated), ArchivedAt: &at}
+	}
+
+	tests := []struct {
+		name      string
+		cached    []linear.Issue
+		watermark time.Time
+		synced    []linear.SyncedIssue
+		want      []string
+		wantMark  time.Time
+	}{
+		{
+			name:     "first sync",
+			synced:   []linear.SyncedIssue{synced("a", 2), synced("b", 3)},
+			want:     []string{"b", "a"},
+			wantMark: day(3),
+		},
+		{
+			name:      "watermark advances to the latest update",
+			cached:    []linear.Issue{issue("a", 2)},
+			watermark: day(2),
+			synced:    []linear.SyncedIssue{synced("b", 5), synced("a", 4)},
+			want:      []string{"b", "a"},
+			wantMark:  day(5),
+		},
+		{
+			name:      "nothing synced keeps the watermark",
+			cached:    []linear.Issue{issue("a", 2)},
+			watermark: day(2),
+			want:      []string{"a"},
+			wantMark:  day(2),
+		},
+		{
+			name:      "archived issues are dropped",
+			cached:    []linear.Issue{issue("a", 2), issue("b", 2)},
+			watermark: day(2),
+			synced:    []linear.SyncedIssue{archived("a", 3)},
+			want:      []string{"b"},
+			wantMark:  day(3),
+		},
+		{
+			name:      "newer cached copy is kept",
+			cached:    []linear.Issue{issue("a", 6)},
+			watermark: day(2),
+			synced:    []linear.SyncedIssue{synced("a", 4)},
+			want:      []string{"a"},
+			wantMark:  day(4),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := Open("")
+			c.PutIssues(tt.cached...)
+			c.data.IssuesWatermark = tt.watermark
+
+			c.ApplyIssues(tt.synced)
+			var ids []string
+			for _, issue := range c.Issues() {
+				ids = append(ids, issue.ID)
+			}
+			if !slices.Equal(ids, tt.want) {
+				t.Errorf("issues = %v, want %v", ids, tt.want)
+			}
+			if watermark, _ := c.Watermarks(); !watermark.Equal(tt.wantMark) {
+				t.Errorf("watermark = %v, want %v", watermark, tt.wantMark)
+			}
+		})
+	}
+}
+
+func TestPutIssues(t *testing.T) {
+	older := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	newer := older.Add(time.Hour)
+
+	tests := []struct {
+		name   string
+		cached time.Time
+		put    time.Time
+		want   string
+	}{
+		{"new issue", time.Time{}, older, "put"},
+		{"newer copy replaces", older, newer, "put"},
+		{"same update time replaces", older, older, "put"},
+		{"older copy is ignored", newer, older, "cached"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := Open("")
+			if !tt.cached.IsZero() {
+				c.PutIssues(linear.Issue{ID: "a", Title: "cached", UpdatedAt: tt.cached})
+			}
+			c.PutIssues(linear.Issue{ID: "a", Title: "put", UpdatedAt: tt.put})
+
+			issue, ok := c.Issue("a")
+			if !ok || issue.Title != tt.want {
+				t.Errorf("cached title = %q, want %q", issue.Title, tt.want)
+			}
+		})
+	}
+}
+
+func TestResetIssues(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cache.json")
+	mark := time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)
+
+	c := Open(path)
+	c.ApplyIssues([]linear.SyncedIssue{{Issue: linear.Issue{ID: "a", UpdatedAt: mark}}})
+	c.ApplyProjects([]linear.SyncedProject{{Project: linear.Project{ID: "p", UpdatedAt: mark}}})
+	c.SetIssuesComplete(true)
+	c.ResetIssues()
+	if err := c.Save(); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+
+	// The reset survives a reopen, and leaves the projects alone
+	reopened := Open(path)
+	issues, projects := reopened.Watermarks()
+	if !issues.IsZero() || reopened.IssuesComplete() || len(reopened.Issues()) != 0 {
+		t.Errorf("after reset: watermark %v, complete %v, %d issues, want none", issues, reopened.IssuesComplete(), len(reopened.Issues()))
+	}
+	if !projects.Equal(mark) || len(reopened.Projects()) != 1 {
+		t.Errorf("after reset: projects watermark %v with %d projects, want %v with 1", projects, len(reopened.Projects()), mark)
+	}
+}
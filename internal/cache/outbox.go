@@ -0,0 +1,158 @@
+package cache
+
+import (
+	"encoding/json"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/linear-tui/linear-tui/internal/domain"
+)
+
+// Kinds of Edit
+const (
+	EditStatus  = "status"
+	EditComment = "comment"
+	EditCreate  = "create"
+)
+
+// Edit is a change made while Linear could not be reached, waiting to be
+// sent
+type Edit struct {
+	// ID identifies the edit. For EditCreate it is the ID the issue is
+	// known by until it has been created.
+	ID       string    `json:"id"`
+	Kind     string    `json:"kind"`
+	QueuedAt time.Time `json:"queuedAt"`
+
+	// IssueID is the ID of the issue whose status is changed or which is
+	// commented on, and Identifier its identifier, such as ENG-123. For a
+	// status change, BaseUpdatedAt is when the issue was last updated as
+	// it was seen when the change was made; an issue updated on Linear
+	// since conflicts with the change.
+	IssueID       string    `json:"issueId,omitempty"`
+	Identifier    string    `json:"identifier,omitempty"`
+	BaseUpdatedAt time.Time `json:"baseUpdatedAt,omitzero"`
+
+	// StateID and StateName are the new status of an EditStatus, Body the
+	// text of an EditComment and Issue the issue of an EditCreate
+	StateID   string           `json:"stateId,omitempty"`
+	StateName string           `json:"stateName,omitempty"`
+	Body      string           `json:"body,omitempty"`
+	Issue     *domain.NewIssue `json:"issue,omitempty"`
+}
+
+// Outbox is the queue of edits made while Linear could not be reached, in
+// the order they were made. It is saved on every change, so queued edits
+// survive a restart. It is safe for concurrent use.
+type Outbox struct {
+	path string
+
+	mu    sync.Mutex
+	edits []Edit
+}
+
+// OpenOutbox reads the outbox file at path. A missing or unreadable file
+// gives an empty outbox, as does an empty path, in which case the outbox is
+// only kept in memory.
+func OpenOutbox(path string) *Outbox {
+	o := &Outbox{path: path}
+	if path == "" {
+		return o
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return o
+	}
+	var edits []Edit
+	if err := json.Unmarshal(data, &edits); err == nil {
+		o.edits = edits
+	}
+	return o
+}
+
+// Add queues an edit
+func (o *Outbox) Add(edit Edit) error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	o.edits = append(o.edits, edit)
+	return o.save()
+}
+
+// Edits returns the queued edits, oldest first
+func (o *Outbox) Edits() []Edit {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	return append([]Edit(nil), o.edits...)
+}
+
+// Len returns the number of queued edits
+func (o *Outbox) Len() int {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	return len(o.edits)
+}
+
+// Remove drops a sent edit
+func (o *Outbox) Remove(id string) error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	for i, edit := range o.edits {
+		if edit.ID == id {
+			o.edits = append(o.edits[:i], o.edits[i+1:]...)
+			break
+		}
+	}
+	return o.save()
+}
+
+// Reassign points the queued edits of the issue with ID from, and the new
+// issues queued as its sub-issues, at the issue with ID to and the given
+// identifier, once an issue created offline has been created
+func (o *Outbox) Reassign(from, to, identifier string) error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	for i := range o.edits {
+		edit := &o.edits[i]
+		if edit.IssueID == from {
+			edit.IssueID, edit.Identifier = to, identifier
+		}
+		if edit.Issue != nil && edit.Issue.ParentID == from {
+			edit.Issue.ParentID = to
+		}
+	}
+	return o.save()
+}
+
+// Rebase records that the issue with ID issueID was last updated at
+// updatedAt by an edit that has been sent, so that the edits queued after
+// it are checked for conflicts from then on
+func (o *Outbox) Rebase(issueID string, updatedAt time.Time) error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	for i := range o.edits {
+		if o.edits[i].IssueID == issueID {
+			o.edits[i].BaseUpdatedAt = updatedAt
+		}
+	}
+	return o.save()
+}
+
+// save writes the outbox to its file. The caller holds o.mu.
+func (o *Outbox) save() error {
+	if o.path == "" {
+		return nil
+	}
+	data, err := json.Marshal(o.edits)
+	if err != nil {
+		return err
+	}
+	return writeFile(o.path, data)
+}
@@ -10,6 +10,21 @@ type Config struct {
 	LinearAPIKey string `json:"linear_api_key"`
 	Theme        Theme  `json:"theme"`
 	DebugMode    bool   `json:"debug_mode"`
+
+	// ExternalEditor writes comments in $EDITOR instead of in the TUI
+	ExternalEditor bool `json:"external_editor"`
+
+	// DefaultTeamID is the team picked with the team switcher, whose issues
+	// are shown and which new issues go to. AllTeams shows the issues of
+	// every team instead.
+	DefaultTeamID string `json:"default_team_id,omitempty"`
+	AllTeams      bool   `json:"all_teams,omitempty"`
+
+	// fileAPIKey and fileDebugMode are the settings as read from the config
+	// file, before the environment overrides them, so that Save does not
+	// write the environment's values to the file
+	fileAPIKey    string
+	fileDebugMode bool
 }
 
 type Theme struct {
@@ -46,6 +61,8 @@ func LoadConfig() (*Config, error) {
 	if err := json.Unmarshal(data, &config); err != nil {
 		return nil, err
 	}
+	config.fileAPIKey = config.LinearAPIKey
+	config.fileDebugMode = config.DebugMode
 
 	// Environment variable takes precedence
 	if apiKey := os.Getenv("LINEAR_API_KEY"); apiKey != "" {
@@ -79,7 +96,11 @@ func (c *Config) Save() error {
 
 	configPath := filepath.Join(configDir, "config.json")
 
-	data, err := json.MarshalIndent(c, "", "  ")
+	saved := *c
+	saved.LinearAPIKey = c.fileAPIKey
+	saved.DebugMode = c.fileDebugMode
+
+	data, err := json.MarshalIndent(saved, "", "  ")
 	if err != nil {
 		return err
 	}
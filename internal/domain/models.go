@@ -9,9 +9,65 @@ type Issue struct {
 	Title       string
 	Description string
 	Status      string
+	StateType   string // Workflow state type (e.g., "started")
 	Priority    string
 	Assignee    string
+	Team        string
+	Project     string
+	Cycle       string // Cycle name, e.g. "Cycle 12"
+	Parent      string // Display ID of the parent issue, if it is a sub-issue
+	Labels      []Label
 	CreatedAt   time.Time
+	UpdatedAt   time.Time
+
+	// Linear IDs and values of the fields above, for editing the issue
+	TeamID         string
+	StateID        string
+	PriorityNumber int
+	AssigneeID     string
+	ProjectID      string
+	CycleID        string
+	CycleNumber    int
+	ParentID       string
+}
+
+// Label represents a label on a Linear issue in the UI layer
+type Label struct {
+	ID    string
+	Name  string
+	Color string // Hex color, e.g. "#eb5757"
+}
+
+// Relation types between issues, as seen from one of them
+const (
+	RelationBlocks      = "Blocks"
+	RelationBlockedBy   = "Blocked by"
+	RelationRelated     = "Related to"
+	RelationDuplicateOf = "Duplicate of"
+	RelationDuplicateBy = "Duplicated by"
+)
+
+// IssueLink is a reference to an issue linked to another one
+type IssueLink struct {
+	ID        string // Display ID (e.g., "PED-35")
+	LinearID  string
+	Title     string
+	Status    string
+	StateType string
+}
+
+// Relation is a link from an issue to another one, of one of the Relation
+// types
+type Relation struct {
+	Type  string
+	Issue IssueLink
+}
+
+// IssueRelations are the issues linked to an issue
+type IssueRelations struct {
+	Parent    *IssueLink
+	Children  []IssueLink
+	Relations []Relation
 }
 
 // Project represents a Linear project in the UI layer
@@ -21,5 +77,120 @@ type Project struct {
 	Description string
 	Status      string
 	Progress    float64
+	TargetDate  time.Time
 	CreatedAt   time.Time
 }
+
+// ProjectDetails is a project with the details shown when it is opened
+type ProjectDetails struct {
+	Project
+	Lead       string
+	Members    []string
+	StartDate  time.Time
+	Health     string // "On track", "At risk" or "Off track"; empty before the first project update
+	Milestones []Milestone
+	States     []StateCount // Issues per workflow state, in workflow order
+}
+
+// Milestone is a stage of a project
+type Milestone struct {
+	Name       string
+	TargetDate time.Time
+}
+
+// StateCount is the number of issues in a workflow state. States of
+// different teams with the same name are counted together.
+type StateCount struct {
+	Name  string
+	Type  string
+	Color string
+	Count int
+}
+
+// Cycle represents a team's cycle in the UI layer
+type Cycle struct {
+	ID        string
+	Number    int
+	Name      string // The cycle's name, or "Cycle N" if it has none
+	Team      string
+	TeamID    string
+	Status    string // "Current", "Upcoming" or "Past"
+	StartsAt  time.Time
+	EndsAt    time.Time
+	Scope     int // Number of issues in the cycle
+	Completed int // Number of those that are completed
+	Progress  float64
+}
+
+// Comment represents a comment on a Linear issue in the UI layer
+type Comment struct {
+	ID        string
+	Body      string
+	Author    string
+	ParentID  string // ID of the comment this replies to, if any
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	Queued    bool // Written offline and waiting to be sent to Linear
+}
+
+// Fields of an issue whose changes are shown in its history
+const (
+	HistoryStatus   = "Status"
+	HistoryAssignee = "Assignee"
+	HistoryPriority = "Priority"
+	HistoryLabels   = "Labels"
+)
+
+// HistoryEvent is a change to a field of an issue. From and To are the
+// values before and after; for labels they are the labels removed and
+// added, comma separated. Empty values mean none.
+type HistoryEvent struct {
+	ID        string
+	Actor     string
+	Field     string // One of the History fields
+	From      string
+	To        string
+	CreatedAt time.Time
+}
+
+// Notification represents a notification in the user's inbox in the UI
+// layer
+type Notification struct {
+	ID              string
+	Reason          string // What happened, e.g. "Assigned to you"
+	Actor           string
+	IssueID         string // Linear ID of the issue it is about, if any
+	IssueIdentifier string // Display ID of the issue (e.g., "PED-35")
+	IssueTitle      string
+	Read            bool
+	SnoozedUntil    time.Time
+	CreatedAt       time.Time
+}
+
+// NewIssue holds the fields of an issue about to be created. References to
+// other entities are Linear IDs; empty ones are left unset.
+type NewIssue struct {
+	Title       string
+	Description string
+	TeamID      string
+	StateID     string
+	Priority    int // Linear priority number, 0 for none
+	AssigneeID  string
+	ProjectID   string
+	LabelIDs    []string
+	ParentID    string // Makes the issue a sub-issue of this one
+}
+
+// IssueUpdate holds the fields of an issue to change. Nil fields are left
+// as they are, so that only what was edited is sent to Linear. A pointer to
+// an empty ID clears the field, such as unassigning the issue.
+type IssueUpdate struct {
+	Title       *string
+	Description *string
+	StateID     *string
+	Priority    *int // Linear priority number, 0 for none
+	AssigneeID  *string
+	ProjectID   *string
+	CycleID     *string
+	LabelIDs    []string // Replaces the labels when not nil; empty removes them all
+}
@@ -5,48 +5,99 @@ import (
 	"fmt"
 )
 
-// GetIssues retrieves issues for a team
-func (c *Client) GetIssues(ctx context.Context, teamID string, limit int) ([]Issue, error) {
-	c.debugLog.LogInfo("Fetching issues for team %s (limit: %d)", teamID, limit)
+// issueFields is the fragment of the fields fetched for an issue, shared by
+// the queries and mutations that return issues
+const issueFields = `
+	fragment IssueFields on Issue {
+		id
+		identifier
+		title
+		description
+		priority
+		createdAt
+		updatedAt
+		state {
+			id
+			name
+			type
+			color
+		}
+		assignee {
+			id
+			name
+			email
+			avatarUrl
+		}
+		team {
+			id
+			name
+			key
+		}
+		project {
+			id
+			name
+		}
+		cycle {
+			id
+			number
+			name
+		}
+		creator {
+			id
+			name
+		}
+		parent {
+			id
+			identifier
+			title
+		}
+		labels {
+			nodes {
+				id
+				name
+				color
+			}
+		}
+	}
+`
+
+// GetIssues retrieves up to limit issues matching filter, following pagination
+func (c *Client) GetIssues(ctx context.Context, filter IssueFilter, limit int) ([]Issue, error) {
+	c.debugLog.LogInfo("Fetching issues (limit: %d)", limit)
+
+	issues, err := FetchAll(ctx, c.IssuesIterator(filter, min(limit, MaxPageSize)), limit)
+	if err != nil {
+		return nil, err
+	}
+
+	c.debugLog.LogInfo("Successfully fetched %d issues", len(issues))
+	return issues, nil
+}
+
+// IssuesIterator returns an iterator over the issues matching filter
+func (c *Client) IssuesIterator(filter IssueFilter, pageSize int) *Iterator[Issue] {
+	return NewIterator(pageSize, func(ctx context.Context, opts PageOptions) (*Page[Issue], error) {
+		return c.GetIssuesPage(ctx, filter, opts)
+	})
+}
+
+// GetIssuesPage retrieves a single page of issues matching filter
+func (c *Client) GetIssuesPage(ctx context.Context, filter IssueFilter, opts PageOptions) (*Page[Issue], error) {
+	if err := filter.Validate(); err != nil {
+		return nil, err
+	}
+
+	c.debugLog.LogInfo("Fetching issues page (after: %q)", opts.After)
 	query := `
-		query GetIssues($teamId: ID!, $first: Int!) {
+		query GetIssues($filter: IssueFilter, $first: Int!, $after: String) {
 			issues(
-				filter: { 
-					team: { id: { eq: $teamId } },
-					state: { type: { in: ["backlog", "unstarted", "started"] } }
-				}, 
+				filter: $filter,
 				first: $first, 
+				after: $after,
 				orderBy: updatedAt
 			) {
 				nodes {
-					id
-					identifier
-					title
-					description
-					priority
-					createdAt
-					updatedAt
-					state {
-						id
-						name
-						type
-						color
-					}
-					assignee {
-						id
-						name
-						email
-						avatarUrl
-					}
-					team {
-						id
-						name
-						key
-					}
-					project {
-						id
-						name
-					}
+					...IssueFields
 				}
 				pageInfo {
 					hasNextPage
@@ -56,12 +107,10 @@ func (c *Client) GetIssues(ctx context.Context, teamID string, limit int) ([]Iss
 				}
 			}
 		}
-	`
+	` + issueFields
 
-	variables := map[string]interface{}{
-		"teamId": teamID,
-		"first":  limit,
-	}
+	variables := opts.variables()
+	variables["filter"] = filter.GraphQL()
 
 	var response IssuesResponse
 	err := c.executeWithRetry(ctx, func() error {
@@ -73,8 +122,70 @@ func (c *Client) GetIssues(ctx context.Context, teamID string, limit int) ([]Iss
 		return nil, err
 	}
 
-	c.debugLog.LogInfo("Successfully fetched %d issues for team %s", len(response.Issues.Nodes), teamID)
-	return response.Issues.Nodes, nil
+	return &Page[Issue]{
+		Nodes:    response.Issues.Nodes,
+		PageInfo: response.Issues.PageInfo,
+	}, nil
+}
+
+// SearchIssuesIterator returns an iterator over the issues matching a
+// full-text search term and filter
+func (c *Client) SearchIssuesIterator(term string, filter IssueFilter, pageSize int) *Iterator[Issue] {
+	return NewIterator(pageSize, func(ctx context.Context, opts PageOptions) (*Page[Issue], error) {
+		return c.SearchIssuesPage(ctx, term, filter, opts)
+	})
+}
+
+// SearchIssuesPage retrieves a single page of the issues matching a
+// full-text search term and filter, most relevant first
+func (c *Client) SearchIssuesPage(ctx context.Context, term string, filter IssueFilter, opts PageOptions) (*Page[Issue], error) {
+	if term == "" {
+		return nil, NewLinearError(ErrorTypeValidation, "search term cannot be empty", 0)
+	}
+	if err := filter.Validate(); err != nil {
+		return nil, err
+	}
+
+	c.debugLog.LogInfo("Searching issues for %q (after: %q)", term, opts.After)
+	query := `
+		query SearchIssues($term: String!, $filter: IssueFilter, $first: Int!, $after: String) {
+			searchIssues(
+				term: $term,
+				filter: $filter,
+				first: $first,
+				after: $after
+			) {
+				nodes {
+					...IssueFields
+				}
+				pageInfo {
+					hasNextPage
+					hasPreviousPage
+					startCursor
+					endCursor
+				}
+			}
+		}
+	` + issueFields
+
+	variables := opts.variables()
+	variables["term"] = term
+	variables["filter"] = filter.GraphQL()
+
+	var response SearchIssuesResponse
+	err := c.executeWithRetry(ctx, func() error {
+		return c.executeGraphQL(ctx, query, variables, &response)
+	})
+
+	if err != nil {
+		c.debugLog.LogError("Failed to search issues", err)
+		return nil, err
+	}
+
+	return &Page[Issue]{
+		Nodes:    response.SearchIssues.Nodes,
+		PageInfo: response.SearchIssues.PageInfo,
+	}, nil
 }
 
 // GetIssueByID retrieves a single issue by its ID
@@ -83,37 +194,10 @@ func (c *Client) GetIssueByID(ctx context.Context, issueID string) (*Issue, erro
 	query := `
 		query GetIssue($id: String!) {
 			issue(id: $id) {
-				id
-				identifier
-				title
-				description
-				priority
-				createdAt
-				updatedAt
-				state {
-					id
-					name
-					type
-					color
-				}
-				assignee {
-					id
-					name
-					email
-					avatarUrl
-				}
-				team {
-					id
-					name
-					key
-				}
-				project {
-					id
-					name
-				}
+				...IssueFields
 			}
 		}
-	`
+	` + issueFields
 
 	variables := map[string]interface{}{
 		"id": issueID,
@@ -136,12 +220,30 @@ func (c *Client) GetIssueByID(ctx context.Context, issueID string) (*Issue, erro
 	return &response.Issue, nil
 }
 
-// GetProjects retrieves projects for a team
+// GetProjects retrieves all projects, following pagination
 func (c *Client) GetProjects(ctx context.Context, teamID string) ([]Project, error) {
 	c.debugLog.LogInfo("Fetching projects for team %s", teamID)
+
+	projects, err := FetchAll(ctx, c.ProjectsIterator(MaxPageSize), 0)
+	if err != nil {
+		return nil, err
+	}
+
+	c.debugLog.LogInfo("Successfully fetched %d projects for team %s", len(projects), teamID)
+	return projects, nil
+}
+
+// ProjectsIterator returns an iterator over all projects
+func (c *Client) ProjectsIterator(pageSize int) *Iterator[Project] {
+	return NewIterator(pageSize, c.GetProjectsPage)
+}
+
+// GetProjectsPage retrieves a single page of projects
+func (c *Client) GetProjectsPage(ctx context.Context, opts PageOptions) (*Page[Project], error) {
+	c.debugLog.LogInfo("Fetching projects page (after: %q)", opts.After)
 	query := `
-		query GetProjects {
-			projects(orderBy: updatedAt) {
+		query GetProjects($first: Int!, $after: String) {
+			projects(first: $first, after: $after, orderBy: updatedAt) {
 				nodes {
 					id
 					name
@@ -150,6 +252,7 @@ func (c *Client) GetProjects(ctx context.Context, teamID string) ([]Project, err
 					progress
 					startDate
 					targetDate
+					updatedAt
 				}
 				pageInfo {
 					hasNextPage
@@ -161,9 +264,11 @@ func (c *Client) GetProjects(ctx context.Context, teamID string) ([]Project, err
 		}
 	`
 
+	variables := opts.variables()
+
 	var response ProjectsResponse
 	err := c.executeWithRetry(ctx, func() error {
-		return c.executeGraphQL(ctx, query, nil, &response)
+		return c.executeGraphQL(ctx, query, variables, &response)
 	})
 
 	if err != nil {
@@ -171,16 +276,35 @@ func (c *Client) GetProjects(ctx context.Context, teamID string) ([]Project, err
 		return nil, err
 	}
 
-	c.debugLog.LogInfo("Successfully fetched %d projects for team %s", len(response.Projects.Nodes), teamID)
-	return response.Projects.Nodes, nil
+	return &Page[Project]{
+		Nodes:    response.Projects.Nodes,
+		PageInfo: response.Projects.PageInfo,
+	}, nil
 }
 
-// GetTeams retrieves all teams
+// GetTeams retrieves all teams, following pagination
 func (c *Client) GetTeams(ctx context.Context) ([]Team, error) {
 	c.debugLog.LogInfo("Fetching all teams")
+
+	teams, err := FetchAll(ctx, c.TeamsIterator(MaxPageSize), 0)
+	if err != nil {
+		return nil, err
+	}
+
+	c.debugLog.LogInfo("Successfully fetched %d teams", len(teams))
+	return teams, nil
+}
+
+// TeamsIterator returns an iterator over all teams
+func (c *Client) TeamsIterator(pageSize int) *Iterator[Team] {
+	return NewIterator(pageSize, c.GetTeamsPage)
+}
+
+// GetTeamsPage retrieves a single page of teams
+func (c *Client) GetTeamsPage(ctx context.Context, opts PageOptions) (*Page[Team], error) {
 	query := `
-		query GetTeams {
-			teams {
+		query GetTeams($first: Int!, $after: String) {
+			teams(first: $first, after: $after) {
 				nodes {
 					id
 					name
@@ -197,9 +321,11 @@ func (c *Client) GetTeams(ctx context.Context) ([]Team, error) {
 		}
 	`
 
+	variables := opts.variables()
+
 	var response TeamsResponse
 	err := c.executeWithRetry(ctx, func() error {
-		return c.executeGraphQL(ctx, query, nil, &response)
+		return c.executeGraphQL(ctx, query, variables, &response)
 	})
 
 	if err != nil {
@@ -207,18 +333,31 @@ func (c *Client) GetTeams(ctx context.Context) ([]Team, error) {
 		return nil, err
 	}
 
-	c.debugLog.LogInfo("Successfully fetched %d teams", len(response.Teams.Nodes))
-	return response.Teams.Nodes, nil
+	return &Page[Team]{
+		Nodes:    response.Teams.Nodes,
+		PageInfo: response.Teams.PageInfo,
+	}, nil
 }
 
-// GetUsers retrieves all users
+// GetUsers retrieves all users, following pagination
 func (c *Client) GetUsers(ctx context.Context) ([]User, error) {
+	return FetchAll(ctx, c.UsersIterator(MaxPageSize), 0)
+}
+
+// UsersIterator returns an iterator over all users
+func (c *Client) UsersIterator(pageSize int) *Iterator[User] {
+	return NewIterator(pageSize, c.GetUsersPage)
+}
+
+// GetUsersPage retrieves a single page of users
+func (c *Client) GetUsersPage(ctx context.Context, opts PageOptions) (*Page[User], error) {
 	query := `
-		query GetUsers {
-			users {
+		query GetUsers($first: Int!, $after: String) {
+			users(first: $first, after: $after) {
 				nodes {
 					id
 					name
+					displayName
 					email
 					avatarUrl
 				}
@@ -232,16 +371,21 @@ func (c *Client) GetUsers(ctx context.Context) ([]User, error) {
 		}
 	`
 
+	variables := opts.variables()
+
 	var response UsersResponse
 	err := c.executeWithRetry(ctx, func() error {
-		return c.executeGraphQL(ctx, query, nil, &response)
+		return c.executeGraphQL(ctx, query, variables, &response)
 	})
 
 	if err != nil {
 		return nil, err
 	}
 
-	return response.Users.Nodes, nil
+	return &Page[User]{
+		Nodes:    response.Users.Nodes,
+		PageInfo: response.Users.PageInfo,
+	}, nil
 }
 
 // CreateIssue creates a new issue
@@ -251,63 +395,15 @@ func (c *Client) CreateIssue(ctx context.Context, input CreateIssueInput) (*Issu
 			issueCreate(input: $input) {
 				success
 				issue {
-					id
-					identifier
-					title
-					description
-					priority
-					createdAt
-					updatedAt
-					state {
-						id
-						name
-						type
-						color
-					}
-					assignee {
-						id
-						name
-						email
-						avatarUrl
-					}
-					team {
-						id
-						name
-						key
-					}
-					project {
-						id
-						name
-					}
+					...IssueFields
 				}
 			}
 		}
-	`
-
-	// Build input variables
-	inputMap := map[string]interface{}{
-		"title":  input.Title,
-		"teamId": input.TeamID,
-	}
-
-	if input.Description != "" {
-		inputMap["description"] = input.Description
-	}
-	if input.Priority > 0 {
-		inputMap["priority"] = input.Priority
-	}
-	if input.AssigneeID != "" {
-		inputMap["assigneeId"] = input.AssigneeID
-	}
-	if input.ProjectID != "" {
-		inputMap["projectId"] = input.ProjectID
-	}
-	if input.StateID != "" {
-		inputMap["stateId"] = input.StateID
-	}
+	` + issueFields
 
+	// Unset fields of the input are left out when it is marshaled
 	variables := map[string]interface{}{
-		"input": inputMap,
+		"input": input,
 	}
 
 	var response struct {
@@ -339,64 +435,17 @@ func (c *Client) UpdateIssue(ctx context.Context, id string, input UpdateIssueIn
 			issueUpdate(id: $id, input: $input) {
 				success
 				issue {
-					id
-					identifier
-					title
-					description
-					priority
-					createdAt
-					updatedAt
-					state {
-						id
-						name
-						type
-						color
-					}
-					assignee {
-						id
-						name
-						email
-						avatarUrl
-					}
-					team {
-						id
-						name
-						key
-					}
-					project {
-						id
-						name
-					}
+					...IssueFields
 				}
 			}
 		}
-	`
-
-	// Build input variables
-	inputMap := make(map[string]interface{})
-
-	if input.Title != "" {
-		inputMap["title"] = input.Title
-	}
-	if input.Description != "" {
-		inputMap["description"] = input.Description
-	}
-	if input.Priority > 0 {
-		inputMap["priority"] = input.Priority
-	}
-	if input.AssigneeID != "" {
-		inputMap["assigneeId"] = input.AssigneeID
-	}
-	if input.ProjectID != "" {
-		inputMap["projectId"] = input.ProjectID
-	}
-	if input.StateID != "" {
-		inputMap["stateId"] = input.StateID
-	}
+	` + issueFields
 
+	// Unset fields of the input are left out when it is marshaled, and
+	// null ones clear the field
 	variables := map[string]interface{}{
 		"id":    id,
-		"input": inputMap,
+		"input": input,
 	}
 
 	var response struct {
@@ -421,8 +470,84 @@ func (c *Client) UpdateIssue(ctx context.Context, id string, input UpdateIssueIn
 	return &response.IssueUpdate.Issue, nil
 }
 
-// CreateComment creates a comment on an issue
-func (c *Client) CreateComment(ctx context.Context, issueID, body string) (*Comment, error) {
+// GetIssueComments retrieves up to limit comments on an issue, following
+// pagination
+func (c *Client) GetIssueComments(ctx context.Context, issueID string, limit int) ([]Comment, error) {
+	c.debugLog.LogInfo("Fetching comments for issue %s (limit: %d)", issueID, limit)
+
+	comments, err := FetchAll(ctx, c.IssueCommentsIterator(issueID, min(limit, MaxPageSize)), limit)
+	if err != nil {
+		return nil, err
+	}
+
+	c.debugLog.LogInfo("Successfully fetched %d comments", len(comments))
+	return comments, nil
+}
+
+// IssueCommentsIterator returns an iterator over the comments on an issue
+func (c *Client) IssueCommentsIterator(issueID string, pageSize int) *Iterator[Comment] {
+	return NewIterator(pageSize, func(ctx context.Context, opts PageOptions) (*Page[Comment], error) {
+		return c.GetIssueCommentsPage(ctx, issueID, opts)
+	})
+}
+
+// GetIssueCommentsPage retrieves a single page of the comments on an issue,
+// oldest first
+func (c *Client) GetIssueCommentsPage(ctx context.Context, issueID string, opts PageOptions) (*Page[Comment], error) {
+	query := `
+		query GetIssueComments($id: String!, $first: Int!, $after: String) {
+			issue(id: $id) {
+				comments(first: $first, after: $after, orderBy: createdAt) {
+					nodes {
+						id
+						body
+						createdAt
+						updatedAt
+						user {
+							id
+							name
+							displayName
+							email
+							avatarUrl
+						}
+						parent {
+							id
+						}
+					}
+					pageInfo {
+						hasNextPage
+						hasPreviousPage
+						startCursor
+						endCursor
+					}
+				}
+			}
+		}
+	`
+
+	variables := opts.variables()
+	variables["id"] = issueID
+
+	var response IssueCommentsResponse
+	err := c.executeWithRetry(ctx, func() error {
+		return c.executeGraphQL(ctx, query, variables, &response)
+	})
+
+	if err != nil {
+		c.debugLog.LogError("Failed to fetch comments", err)
+		return nil, err
+	}
+
+	return &Page[Comment]{
+		Nodes:    response.Issue.Comments.Nodes,
+		PageInfo: response.Issue.Comments.PageInfo,
+	}, nil
+}
+
+// CreateComment creates a comment on an issue. id, if not empty, is a
+// UUID the comment is created with, which makes Linear refuse to create it
+// a second time.
+func (c *Client) CreateComment(ctx context.Context, id, issueID, body string) (*Comment, error) {
 	mutation := `
 		mutation CreateComment($input: CommentCreateInput!) {
 			commentCreate(input: $input) {
@@ -438,16 +563,24 @@ func (c *Client) CreateComment(ctx context.Context, issueID, body string) (*Comm
 						email
 						avatarUrl
 					}
+					issue {
+						id
+						updatedAt
+					}
 				}
 			}
 		}
 	`
 
+	input := map[string]interface{}{
+		"issueId": issueID,
+		"body":    body,
+	}
+	if id != "" {
+		input["id"] = id
+	}
 	variables := map[string]interface{}{
-		"input": map[string]interface{}{
-			"issueId": issueID,
-			"body":    body,
-		},
+		"input": input,
 	}
 
 	var response struct {
@@ -472,6 +605,48 @@ func (c *Client) CreateComment(ctx context.Context, issueID, body string) (*Comm
 	return &response.CommentCreate.Comment, nil
 }
 
+// GetCommentByID retrieves a single comment by its ID
+func (c *Client) GetCommentByID(ctx context.Context, commentID string) (*Comment, error) {
+	query := `
+		query GetComment($id: String!) {
+			comment(id: $id) {
+				id
+				body
+				createdAt
+				updatedAt
+				user {
+					id
+					name
+					email
+					avatarUrl
+				}
+				issue {
+					id
+					updatedAt
+				}
+			}
+		}
+	`
+
+	variables := map[string]interface{}{
+		"id": commentID,
+	}
+
+	var response struct {
+		Comment Comment `json:"comment"`
+	}
+
+	err := c.executeWithRetry(ctx, func() error {
+		return c.executeGraphQL(ctx, query, variables, &response)
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &response.Comment, nil
+}
+
 // GetIssueStates retrieves available issue states for a team
 func (c *Client) GetIssueStates(ctx context.Context, teamID string) ([]IssueState, error) {
 	query := `
@@ -483,6 +658,7 @@ func (c *Client) GetIssueStates(ctx context.Context, teamID string) ([]IssueStat
 						name
 						type
 						color
+						position
 					}
 				}
 			}
@@ -512,8 +688,9 @@ func (c *Client) GetIssueStates(ctx context.Context, teamID string) ([]IssueStat
 	return response.Team.States.Nodes, nil
 }
 
-// ValidateAPIKey validates the API key by making a simple query
-func (c *Client) ValidateAPIKey(ctx context.Context) error {
+// ValidateAPIKey validates the API key by fetching the authenticated user,
+// who is returned
+func (c *Client) ValidateAPIKey(ctx context.Context) (*User, error) {
 	c.debugLog.LogInfo("Starting API key validation")
 
 	query := `
@@ -521,26 +698,97 @@ func (c *Client) ValidateAPIKey(ctx context.Context) error {
 			viewer {
 				id
 				name
+				displayName
 				email
+				avatarUrl
 			}
 		}
 	`
 
 	var response struct {
-		Viewer struct {
-			ID    string `json:"id"`
-			Name  string `json:"name"`
-			Email string `json:"email"`
-		} `json:"viewer"`
+		Viewer User `json:"viewer"`
 	}
 
-	err := c.executeGraphQL(ctx, query, nil, &response)
+	err := c.executeWithRetry(ctx, func() error {
+		return c.executeGraphQL(ctx, query, nil, &response)
+	})
 	if err != nil {
 		c.debugLog.LogError("API key validation", err)
-		return fmt.Errorf("API key validation failed: %w", err)
+		return nil, fmt.Errorf("API key validation failed: %w", err)
 	}
 
 	c.debugLog.LogInfo("API key validated successfully for user: %s (%s) [ID: %s]",
 		response.Viewer.Name, response.Viewer.Email, response.Viewer.ID)
-	return nil
+	return &response.Viewer, nil
+}
+
+// GetIssueLabels retrieves the labels that can be applied to issues of a
+// team: the team's own labels and the workspace labels
+func (c *Client) GetIssueLabels(ctx context.Context, teamID string) ([]IssueLabel, error) {
+	c.debugLog.LogInfo("Fetching labels for team %s", teamID)
+
+	labels, err := FetchAll(ctx, c.IssueLabelsIterator(teamID, MaxPageSize), DefaultFetchAllLimit)
+	if err != nil {
+		return nil, err
+	}
+
+	c.debugLog.LogInfo("Successfully fetched %d labels", len(labels))
+	return labels, nil
+}
+
+// IssueLabelsIterator returns an iterator over the labels available to a team
+func (c *Client) IssueLabelsIterator(teamID string, pageSize int) *Iterator[IssueLabel] {
+	return NewIterator(pageSize, func(ctx context.Context, opts PageOptions) (*Page[IssueLabel], error) {
+		return c.GetIssueLabelsPage(ctx, teamID, opts)
+	})
+}
+
+// GetIssueLabelsPage retrieves a single page of the labels available to a
+// team
+func (c *Client) GetIssueLabelsPage(ctx context.Context, teamID string, opts PageOptions) (*Page[IssueLabel], error) {
+	query := `
+		query GetIssueLabels($filter: IssueLabelFilter, $first: Int!, $after: String) {
+			issueLabels(filter: $filter, first: $first, after: $after) {
+				nodes {
+					id
+					name
+					color
+					team {
+						id
+						name
+						key
+					}
+				}
+				pageInfo {
+					hasNextPage
+					hasPreviousPage
+					startCursor
+					endCursor
+				}
+			}
+		}
+	`
+
+	variables := opts.variables()
+	variables["filter"] = map[string]interface{}{
+		"or": []interface{}{
+			map[string]interface{}{"team": map[string]interface{}{"id": map[string]interface{}{"eq": teamID}}},
+			map[string]interface{}{"team": map[string]interface{}{"null": true}},
+		},
+	}
+
+	var response IssueLabelsResponse
+	err := c.executeWithRetry(ctx, func() error {
+		return c.executeGraphQL(ctx, query, variables, &response)
+	})
+
+	if err != nil {
+		c.debugLog.LogError("Failed to fetch labels", err)
+		return nil, err
+	}
+
+	return &Page[IssueLabel]{
+		Nodes:    response.IssueLabels.Nodes,
+		PageInfo: response.IssueLabels.PageInfo,
+	}, nil
 }
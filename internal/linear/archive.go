@@ -0,0 +1,63 @@
+package linear
+
+import (
+	"context"
+	"fmt"
+)
+
+// ArchiveIssue archives an issue
+func (c *Client) ArchiveIssue(ctx context.Context, id string) error {
+	return c.mutateByID(ctx, "issueArchive", id, "archive issue")
+}
+
+// UnarchiveIssue restores an archived issue, or one moved to the trash by
+// DeleteIssue
+func (c *Client) UnarchiveIssue(ctx context.Context, id string) error {
+	return c.mutateByID(ctx, "issueUnarchive", id, "unarchive issue")
+}
+
+// DeleteIssue moves an issue to the trash, from which UnarchiveIssue can
+// restore it until it is purged
+func (c *Client) DeleteIssue(ctx context.Context, id string) error {
+	return c.mutateByID(ctx, "issueDelete", id, "delete issue")
+}
+
+// DeleteComment deletes a comment
+func (c *Client) DeleteComment(ctx context.Context, id string) error {
+	return c.mutateByID(ctx, "commentDelete", id, "delete comment")
+}
+
+// mutateByID runs a mutation that takes the ID of what it acts on and
+// reports only whether it succeeded. action describes it in errors.
+func (c *Client) mutateByID(ctx context.Context, name, id, action string) error {
+	mutation := fmt.Sprintf(`
+		mutation Mutate($id: String!) {
+			%s(id: $id) {
+				success
+			}
+		}
+	`, name)
+
+	variables := map[string]interface{}{
+		"id": id,
+	}
+
+	var response map[string]struct {
+		Success bool `json:"success"`
+	}
+
+	err := c.executeWithRetry(ctx, func() error {
+		return c.executeGraphQL(ctx, mutation, variables, &response)
+	})
+
+	if err != nil {
+		c.debugLog.LogError("Failed to "+action, err)
+		return err
+	}
+
+	if !response[name].Success {
+		return NewLinearError(ErrorTypeAPI, "failed to "+action, 200)
+	}
+
+	return nil
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"net/http"
 	"os"
+	"sync/atomic"
 	"time"
 )
 
@@ -21,6 +22,10 @@ type Client struct {
 	rateLimiter *RateLimiter
 	retryConfig RetryConfig
 	debugLog    *DebugLogger
+
+	// unreachable is set while requests fail for lack of a connection to
+	// Linear, and cleared by the next request that succeeds
+	unreachable atomic.Bool
 }
 
 func NewClient(apiKey string) (*Client, error) {
@@ -76,6 +81,7 @@ func (c *Client) executeWithRetry(ctx context.Context, fn func() error) error {
 		err := fn()
 		if err == nil {
 			c.debugLog.LogInfo("Request completed successfully on attempt %d", attempt+1)
+			c.unreachable.Store(false)
 			return nil
 		}
 
@@ -106,5 +112,20 @@ func (c *Client) executeWithRetry(ctx context.Context, fn func() error) error {
 	}
 
 	c.debugLog.LogError("All retry attempts exhausted", lastErr)
+	if linearErr, ok := lastErr.(*LinearError); ok && linearErr.Type == ErrorTypeNetwork {
+		c.unreachable.Store(true)
+	}
 	return lastErr
 }
+
+// SetBaseURL points the client at another GraphQL endpoint than Linear's,
+// such as a local server standing in for it
+func (c *Client) SetBaseURL(url string) {
+	c.baseURL = url
+}
+
+// Reachable reports whether Linear could be reached on the last request.
+// It is true until a request fails for lack of a connection.
+func (c *Client) Reachable() bool {
+	return !c.unreachable.Load()
+}
@@ -0,0 +1,109 @@
+package linear
+
+import (
+	"context"
+	"time"
+)
+
+// Cycle is a team's time-boxed iteration, also known as a sprint. Linear
+// numbers the cycles of each team; only some are given a name.
+//
+// The count histories hold a value per day of the cycle, the last of which
+// is the current count.
+type Cycle struct {
+	ID                         string     `json:"id"`
+	Number                     float64    `json:"number"`
+	Name                       string     `json:"name"`
+	StartsAt                   time.Time  `json:"startsAt"`
+	EndsAt                     time.Time  `json:"endsAt"`
+	CompletedAt                *time.Time `json:"completedAt"`
+	Progress                   float64    `json:"progress"`
+	IsActive                   bool       `json:"isActive"`
+	IsFuture                   bool       `json:"isFuture"`
+	IsPast                     bool       `json:"isPast"`
+	IssueCountHistory          []float64  `json:"issueCountHistory"`
+	CompletedIssueCountHistory []float64  `json:"completedIssueCountHistory"`
+	Team                       *Team      `json:"team"`
+}
+
+type CyclesResponse struct {
+	Cycles struct {
+		Nodes    []Cycle  `json:"nodes"`
+		PageInfo PageInfo `json:"pageInfo"`
+	} `json:"cycles"`
+}
+
+// GetCycles retrieves the cycles of the teams, following pagination
+func (c *Client) GetCycles(ctx context.Context, teamIDs []string) ([]Cycle, error) {
+	c.debugLog.LogInfo("Fetching cycles for %d teams", len(teamIDs))
+
+	cycles, err := FetchAll(ctx, c.CyclesIterator(teamIDs, MaxPageSize), DefaultFetchAllLimit)
+	if err != nil {
+		return nil, err
+	}
+
+	c.debugLog.LogInfo("Successfully fetched %d cycles", len(cycles))
+	return cycles, nil
+}
+
+// CyclesIterator returns an iterator over the cycles of the teams
+func (c *Client) CyclesIterator(teamIDs []string, pageSize int) *Iterator[Cycle] {
+	return NewIterator(pageSize, func(ctx context.Context, opts PageOptions) (*Page[Cycle], error) {
+		return c.GetCyclesPage(ctx, teamIDs, opts)
+	})
+}
+
+// GetCyclesPage retrieves a single page of the cycles of the teams
+func (c *Client) GetCyclesPage(ctx context.Context, teamIDs []string, opts PageOptions) (*Page[Cycle], error) {
+	query := `
+		query GetCycles($filter: CycleFilter, $first: Int!, $after: String) {
+			cycles(filter: $filter, first: $first, after: $after) {
+				nodes {
+					id
+					number
+					name
+					startsAt
+					endsAt
+					completedAt
+					progress
+					isActive
+					isFuture
+					isPast
+					issueCountHistory
+					completedIssueCountHistory
+					team {
+						id
+						name
+						key
+					}
+				}
+				pageInfo {
+					hasNextPage
+					hasPreviousPage
+					startCursor
+					endCursor
+				}
+			}
+		}
+	`
+
+	variables := opts.variables()
+	variables["filter"] = map[string]interface{}{
+		"team": map[string]interface{}{"id": map[string]interface{}{"in": teamIDs}},
+	}
+
+	var response CyclesResponse
+	err := c.executeWithRetry(ctx, func() error {
+		return c.executeGraphQL(ctx, query, variables, &response)
+	})
+
+	if err != nil {
+		c.debugLog.LogError("Failed to fetch cycles", err)
+		return nil, err
+	}
+
+	return &Page[Cycle]{
+		Nodes:    response.Cycles.Nodes,
+		PageInfo: response.Cycles.PageInfo,
+	}, nil
+}
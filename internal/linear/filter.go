@@ -0,0 +1,324 @@
+package linear
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+// Workflow state types used by Linear
+const (
+	StateTypeTriage    = "triage"
+	StateTypeBacklog   = "backlog"
+	StateTypeUnstarted = "unstarted"
+	StateTypeStarted   = "started"
+	StateTypeCompleted = "completed"
+	StateTypeCanceled  = "canceled"
+)
+
+// StateTypes are the workflow state types in the order issues move through
+// them
+var StateTypes = []string{
+	StateTypeTriage, StateTypeBacklog, StateTypeUnstarted,
+	StateTypeStarted, StateTypeCompleted, StateTypeCanceled,
+}
+
+// StateTypeOrder returns where a state type comes in StateTypes; unknown
+// types come last
+func StateTypeOrder(stateType string) int {
+	if i := slices.Index(StateTypes, stateType); i >= 0 {
+		return i
+	}
+	return len(StateTypes)
+}
+
+// OpenStateTypes are the state types of issues that still need work
+var OpenStateTypes = []string{StateTypeBacklog, StateTypeUnstarted, StateTypeStarted}
+
+// Priority values used by Linear. Lower numbers are more urgent, except
+// for PriorityNone.
+const (
+	PriorityNone   = 0
+	PriorityUrgent = 1
+	PriorityHigh   = 2
+	PriorityNormal = 3
+	PriorityLow    = 4
+)
+
+// Relative cycles for IssueFilter.Cycle. Cycles belong to teams, so each
+// issue is matched against the cycles of its own team.
+const (
+	CycleActive   = "active"
+	CycleNext     = "next"
+	CyclePrevious = "previous"
+	CycleNone     = "none"
+)
+
+// IssueFilter selects issues. Zero-valued fields do not filter. Multiple
+// values within a field match any of them, and all set fields must match.
+type IssueFilter struct {
+	TeamIDs []string
+
+	// AssigneeIDs matches issues assigned to any of the users. AssignedToMe
+	// adds the authenticated user, and Unassigned adds issues without an
+	// assignee.
+	AssigneeIDs  []string
+	AssignedToMe bool
+	Unassigned   bool
+
+	// CreatorIDs matches issues created by any of the users
+	CreatorIDs []string
+
+	StateNames []string
+	StateTypes []string
+
+	// MinPriority and MaxPriority bound the numeric priority, inclusive.
+	// Use the Priority constants; note that PriorityUrgent is the lowest
+	// number, so a MaxPriority alone also matches PriorityNone.
+	MinPriority *int
+	MaxPriority *int
+
+	// Labels matches issues that have any of the label names
+	Labels []string
+
+	ProjectID string
+	CycleID   string
+
+	// Cycle matches issues in a cycle relative to the current one, or in
+	// no cycle: one of the Cycle constants. CycleNumber matches issues in
+	// the cycle with that number.
+	Cycle       string
+	CycleNumber int
+
+	CreatedAfter  time.Time
+	CreatedBefore time.Time
+	UpdatedAfter  time.Time
+	UpdatedBefore time.Time
+
+	// Search matches issues whose title or description contains the text
+	Search string
+}
+
+// Validate reports filters that can never match
+func (f IssueFilter) Validate() error {
+	if f.MinPriority != nil && f.MaxPriority != nil && *f.MinPriority > *f.MaxPriority {
+		return NewLinearError(ErrorTypeValidation,
+			fmt.Sprintf("invalid priority range: %d > %d", *f.MinPriority, *f.MaxPriority), 0)
+	}
+	if !f.CreatedAfter.IsZero() && !f.CreatedBefore.IsZero() && f.CreatedAfter.After(f.CreatedBefore) {
+		return NewLinearError(ErrorTypeValidation, "invalid created date range", 0)
+	}
+	if !f.UpdatedAfter.IsZero() && !f.UpdatedBefore.IsZero() && f.UpdatedAfter.After(f.UpdatedBefore) {
+		return NewLinearError(ErrorTypeValidation, "invalid updated date range", 0)
+	}
+	return nil
+}
+
+// GraphQL compiles the filter into Linear's IssueFilter input object
+func (f IssueFilter) GraphQL() map[string]interface{} {
+	filter := make(map[string]interface{})
+	var and []interface{}
+
+	if len(f.TeamIDs) > 0 {
+		filter["team"] = map[string]interface{}{"id": map[string]interface{}{"in": f.TeamIDs}}
+	}
+
+	var assignee []interface{}
+	if len(f.AssigneeIDs) > 0 {
+		assignee = append(assignee, map[string]interface{}{"id": map[string]interface{}{"in": f.AssigneeIDs}})
+	}
+	if f.AssignedToMe {
+		assignee = append(assignee, map[string]interface{}{"isMe": map[string]interface{}{"eq": true}})
+	}
+	if f.Unassigned {
+		assignee = append(assignee, map[string]interface{}{"null": true})
+	}
+	switch len(assignee) {
+	case 0:
+	case 1:
+		filter["assignee"] = assignee[0]
+	default:
+		// Each alternative is a separate assignee filter, so they are
+		// combined at the issue level
+		var or []interface{}
+		for _, a := range assignee {
+			or = append(or, map[string]interface{}{"assignee": a})
+		}
+		and = append(and, map[string]interface{}{"or": or})
+	}
+
+	if len(f.CreatorIDs) > 0 {
+		filter["creator"] = map[string]interface{}{"id": map[string]interface{}{"in": f.CreatorIDs}}
+	}
+
+	state := make(map[string]interface{})
+	if len(f.StateNames) > 0 {
+		state["name"] = map[string]interface{}{"in": f.StateNames}
+	}
+	if len(f.StateTypes) > 0 {
+		state["type"] = map[string]interface{}{"in": f.StateTypes}
+	}
+	if len(state) > 0 {
+		filter["state"] = state
+	}
+
+	priority := make(map[string]interface{})
+	if f.MinPriority != nil {
+		priority["gte"] = *f.MinPriority
+	}
+	if f.MaxPriority != nil {
+		priority["lte"] = *f.MaxPriority
+	}
+	if len(priority) > 0 {
+		filter["priority"] = priority
+	}
+
+	if len(f.Labels) > 0 {
+		filter["labels"] = map[string]interface{}{
+			"some": map[string]interface{}{"name": map[string]interface{}{"in": f.Labels}},
+		}
+	}
+
+	if f.ProjectID != "" {
+		filter["project"] = map[string]interface{}{"id": map[string]interface{}{"eq": f.ProjectID}}
+	}
+	cycle := make(map[string]interface{})
+	if f.CycleID != "" {
+		cycle["id"] = map[string]interface{}{"eq": f.CycleID}
+	}
+	if f.CycleNumber != 0 {
+		cycle["number"] = map[string]interface{}{"eq": f.CycleNumber}
+	}
+	switch f.Cycle {
+	case CycleActive:
+		cycle["isActive"] = map[string]interface{}{"eq": true}
+	case CycleNext:
+		cycle["isNext"] = map[string]interface{}{"eq": true}
+	case CyclePrevious:
+		cycle["isPrevious"] = map[string]interface{}{"eq": true}
+	case CycleNone:
+		cycle = map[string]interface{}{"null": true}
+	}
+	if len(cycle) > 0 {
+		filter["cycle"] = cycle
+	}
+
+	if created := dateRange(f.CreatedAfter, f.CreatedBefore); created != nil {
+		filter["createdAt"] = created
+	}
+	if updated := dateRange(f.UpdatedAfter, f.UpdatedBefore); updated != nil {
+		filter["updatedAt"] = updated
+	}
+
+	if f.Search != "" {
+		and = append(and, map[string]interface{}{
+			"or": []interface{}{
+				map[string]interface{}{"title": map[string]interface{}{"containsIgnoreCase": f.Search}},
+				map[string]interface{}{"description": map[string]interface{}{"containsIgnoreCase": f.Search}},
+			},
+		})
+	}
+
+	if len(and) > 0 {
+		filter["and"] = and
+	}
+
+	return filter
+}
+
+// dateRange builds a date comparator, or nil if neither bound is set
+func dateRange(after, before time.Time) map[string]interface{} {
+	comparator := make(map[string]interface{})
+	if !after.IsZero() {
+		comparator["gte"] = after.UTC().Format(time.RFC3339)
+	}
+	if !before.IsZero() {
+		comparator["lte"] = before.UTC().Format(time.RFC3339)
+	}
+	if len(comparator) == 0 {
+		return nil
+	}
+	return comparator
+}
+
+// Local reports whether Match can evaluate the filter. Relative cycles
+// depend on the current cycle of each team, which issues do not carry.
+func (f IssueFilter) Local() bool {
+	return f.Cycle == "" || f.Cycle == CycleNone
+}
+
+// Match evaluates the filter against an issue held locally, as Linear
+// would. viewerID is the ID of the authenticated user, for AssignedToMe.
+// Filters that are not Local match no issues.
+func (f IssueFilter) Match(issue Issue, viewerID string) bool {
+	if !f.Local() {
+		return false
+	}
+	if len(f.TeamIDs) > 0 && (issue.Team == nil || !slices.Contains(f.TeamIDs, issue.Team.ID)) {
+		return false
+	}
+
+	if len(f.AssigneeIDs) > 0 || f.AssignedToMe || f.Unassigned {
+		switch {
+		case issue.Assignee == nil:
+			if !f.Unassigned {
+				return false
+			}
+		case !slices.Contains(f.AssigneeIDs, issue.Assignee.ID) && !(f.AssignedToMe && issue.Assignee.ID == viewerID):
+			return false
+		}
+	}
+
+	if len(f.CreatorIDs) > 0 && (issue.Creator == nil || !slices.Contains(f.CreatorIDs, issue.Creator.ID)) {
+		return false
+	}
+	if len(f.StateNames) > 0 && !slices.Contains(f.StateNames, issue.State.Name) {
+		return false
+	}
+	if len(f.StateTypes) > 0 && !slices.Contains(f.StateTypes, issue.State.Type) {
+		return false
+	}
+	if f.MinPriority != nil && issue.Priority < *f.MinPriority {
+		return false
+	}
+	if f.MaxPriority != nil && issue.Priority > *f.MaxPriority {
+		return false
+	}
+	if len(f.Labels) > 0 && !slices.ContainsFunc(issue.Labels.Nodes, func(label IssueLabel) bool {
+		return slices.Contains(f.Labels, label.Name)
+	}) {
+		return false
+	}
+
+	if f.ProjectID != "" && (issue.Project == nil || issue.Project.ID != f.ProjectID) {
+		return false
+	}
+	if f.CycleID != "" && (issue.Cycle == nil || issue.Cycle.ID != f.CycleID) {
+		return false
+	}
+	if f.CycleNumber != 0 && (issue.Cycle == nil || int(issue.Cycle.Number) != f.CycleNumber) {
+		return false
+	}
+	if f.Cycle == CycleNone && issue.Cycle != nil {
+		return false
+	}
+
+	if !inRange(issue.CreatedAt, f.CreatedAfter, f.CreatedBefore) || !inRange(issue.UpdatedAt, f.UpdatedAfter, f.UpdatedBefore) {
+		return false
+	}
+
+	if f.Search != "" {
+		search := strings.ToLower(f.Search)
+		if !strings.Contains(strings.ToLower(issue.Title), search) && !strings.Contains(strings.ToLower(issue.Description), search) {
+			return false
+		}
+	}
+	return true
+}
+
+// inRange reports whether t lies within the bounds of a date range, as
+// built by dateRange
+func inRange(t, after, before time.Time) bool {
+	return (after.IsZero() || !t.Before(after)) && (before.IsZero() || !t.After(before))
+}
@@ -0,0 +1,95 @@
+package linear
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestIssueFilterGraphQL(t *testing.T) {
+	priority := func(p int) *int { return &p }
+	day := time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name   string
+		filter IssueFilter
+		want   string
+	}{
+		{
+			name: "empty",
+			want: `{}`,
+		},
+		{
+			name:   "team and open states",
+			filter: IssueFilter{TeamIDs: []string{"team"}, StateTypes: OpenStateTypes},
+			want:   `{"state":{"type":{"in":["backlog","unstarted","started"]}},"team":{"id":{"in":["team"]}}}`,
+		},
+		{
+			name:   "one kind of assignee",
+			filter: IssueFilter{AssigneeIDs: []string{"alice", "bob"}},
+			want:   `{"assignee":{"id":{"in":["alice","bob"]}}}`,
+		},
+		{
+			name:   "me or unassigned",
+			filter: IssueFilter{AssignedToMe: true, Unassigned: true},
+			want:   `{"and":[{"or":[{"assignee":{"isMe":{"eq":true}}},{"assignee":{"null":true}}]}]}`,
+		},
+		{
+			name:   "creator",
+			filter: IssueFilter{CreatorIDs: []string{"alice"}},
+			want:   `{"creator":{"id":{"in":["alice"]}}}`,
+		},
+		{
+			name:   "state names and types",
+			filter: IssueFilter{StateNames: []string{"In Review"}, StateTypes: []string{StateTypeStarted}},
+			want:   `{"state":{"name":{"in":["In Review"]},"type":{"in":["started"]}}}`,
+		},
+		{
+			name:   "priority range",
+			filter: IssueFilter{MinPriority: priority(PriorityUrgent), MaxPriority: priority(PriorityHigh)},
+			want:   `{"priority":{"gte":1,"lte":2}}`,
+		},
+		{
+			name:   "labels and project",
+			filter: IssueFilter{Labels: []string{"bug"}, ProjectID: "apollo"},
+			want:   `{"labels":{"some":{"name":{"in":["bug"]}}},"project":{"id":{"eq":"apollo"}}}`,
+		},
+		{
+			name:   "cycle number",
+			filter: IssueFilter{CycleNumber: 12},
+			want:   `{"cycle":{"number":{"eq":12}}}`,
+		},
+		{
+			name:   "active cycle",
+			filter: IssueFilter{Cycle: CycleActive},
+			want:   `{"cycle":{"isActive":{"eq":true}}}`,
+		},
+		{
+			name:   "no cycle",
+			filter: IssueFilter{Cycle: CycleNone},
+			want:   `{"cycle":{"null":true}}`,
+		},
+		{
+			name:   "date ranges",
+			filter: IssueFilter{CreatedAfter: day, CreatedBefore: day.AddDate(0, 0, 1), UpdatedBefore: day},
+			want:   `{"createdAt":{"gte":"2024-01-31T00:00:00Z","lte":"2024-02-01T00:00:00Z"},"updatedAt":{"lte":"2024-01-31T00:00:00Z"}}`,
+		},
+		{
+			name:   "search",
+			filter: IssueFilter{Search: "crash"},
+			want:   `{"and":[{"or":[{"title":{"containsIgnoreCase":"crash"}},{"description":{"containsIgnoreCase":"crash"}}]}]}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := json.Marshal(tt.filter.GraphQL())
+			if err != nil {
+				t.Fatalf("Marshal failed: %v", err)
+			}
+			if string(data) != tt.want {
+				t.Errorf("GraphQL() = %s, want %s", data, tt.want)
+			}
+		})
+	}
+}
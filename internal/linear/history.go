@@ -0,0 +1,129 @@
+package linear
+
+import (
+	"context"
+	"time"
+)
+
+// IssueHistory is an entry in the history of an issue. An entry records
+// the changes made at once, so several of the from/to pairs can be set.
+// Actor is nil for changes made by Linear or an integration.
+type IssueHistory struct {
+	ID            string       `json:"id"`
+	CreatedAt     time.Time    `json:"createdAt"`
+	Actor         *User        `json:"actor"`
+	FromState     *IssueState  `json:"fromState"`
+	ToState       *IssueState  `json:"toState"`
+	FromAssignee  *User        `json:"fromAssignee"`
+	ToAssignee    *User        `json:"toAssignee"`
+	FromPriority  *float64     `json:"fromPriority"`
+	ToPriority    *float64     `json:"toPriority"`
+	AddedLabels   []IssueLabel `json:"addedLabels"`
+	RemovedLabels []IssueLabel `json:"removedLabels"`
+}
+
+type IssueHistoryResponse struct {
+	Issue struct {
+		History struct {
+			Nodes    []IssueHistory `json:"nodes"`
+			PageInfo PageInfo       `json:"pageInfo"`
+		} `json:"history"`
+	} `json:"issue"`
+}
+
+// GetIssueHistory retrieves up to limit entries of the history of an
+// issue, following pagination
+func (c *Client) GetIssueHistory(ctx context.Context, issueID string, limit int) ([]IssueHistory, error) {
+	c.debugLog.LogInfo("Fetching history for issue %s (limit: %d)", issueID, limit)
+
+	history, err := FetchAll(ctx, c.IssueHistoryIterator(issueID, min(limit, MaxPageSize)), limit)
+	if err != nil {
+		return nil, err
+	}
+
+	c.debugLog.LogInfo("Successfully fetched %d history entries", len(history))
+	return history, nil
+}
+
+// IssueHistoryIterator returns an iterator over the history of an issue
+func (c *Client) IssueHistoryIterator(issueID string, pageSize int) *Iterator[IssueHistory] {
+	return NewIterator(pageSize, func(ctx context.Context, opts PageOptions) (*Page[IssueHistory], error) {
+		return c.GetIssueHistoryPage(ctx, issueID, opts)
+	})
+}
+
+// GetIssueHistoryPage retrieves a single page of the history of an issue
+func (c *Client) GetIssueHistoryPage(ctx context.Context, issueID string, opts PageOptions) (*Page[IssueHistory], error) {
+	query := `
+		query GetIssueHistory($id: String!, $first: Int!, $after: String) {
+			issue(id: $id) {
+				history(first: $first, after: $after) {
+					nodes {
+						id
+						createdAt
+						actor {
+							id
+							name
+						}
+						fromState {
+							id
+							name
+							type
+							color
+						}
+						toState {
+							id
+							name
+							type
+							color
+						}
+						fromAssignee {
+							id
+							name
+						}
+						toAssignee {
+							id
+							name
+						}
+						fromPriority
+						toPriority
+						addedLabels {
+							id
+							name
+							color
+						}
+						removedLabels {
+							id
+							name
+							color
+						}
+					}
+					pageInfo {
+						hasNextPage
+						hasPreviousPage
+						startCursor
+						endCursor
+					}
+				}
+			}
+		}
+	`
+
+	variables := opts.variables()
+	variables["id"] = issueID
+
+	var response IssueHistoryResponse
+	err := c.executeWithRetry(ctx, func() error {
+		return c.executeGraphQL(ctx, query, variables, &response)
+	})
+
+	if err != nil {
+		c.debugLog.LogError("Failed to fetch issue history", err)
+		return nil, err
+	}
+
+	return &Page[IssueHistory]{
+		Nodes:    response.Issue.History.Nodes,
+		PageInfo: response.Issue.History.PageInfo,
+	}, nil
+}
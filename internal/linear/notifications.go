@@ -0,0 +1,133 @@
+package linear
+
+import (
+	"context"
+	"time"
+)
+
+// Notification is a notification in the authenticated user's inbox. Issue
+// and Comment are set for notifications about issues.
+type Notification struct {
+	ID             string     `json:"id"`
+	Type           string     `json:"type"`
+	Actor          *User      `json:"actor"`
+	Issue          *Issue     `json:"issue"`
+	Comment        *Comment   `json:"comment"`
+	ReadAt         *time.Time `json:"readAt"`
+	SnoozedUntilAt *time.Time `json:"snoozedUntilAt"`
+	CreatedAt      time.Time  `json:"createdAt"`
+}
+
+// UpdateNotificationInput is the input for updating a notification. A null
+// ReadAt marks it unread, and a null SnoozedUntilAt unsnoozes it.
+type UpdateNotificationInput struct {
+	ReadAt         Optional[time.Time] `json:"readAt,omitzero"`
+	SnoozedUntilAt Optional[time.Time] `json:"snoozedUntilAt,omitzero"`
+}
+
+type NotificationsResponse struct {
+	Notifications struct {
+		Nodes    []Notification `json:"nodes"`
+		PageInfo PageInfo       `json:"pageInfo"`
+	} `json:"notifications"`
+}
+
+// NotificationsIterator returns an iterator over the authenticated user's
+// notifications
+func (c *Client) NotificationsIterator(pageSize int) *Iterator[Notification] {
+	return NewIterator(pageSize, c.GetNotificationsPage)
+}
+
+// GetNotificationsPage retrieves a single page of the authenticated user's
+// notifications, newest first
+func (c *Client) GetNotificationsPage(ctx context.Context, opts PageOptions) (*Page[Notification], error) {
+	c.debugLog.LogInfo("Fetching notifications page (after: %q)", opts.After)
+	query := `
+		query GetNotifications($first: Int!, $after: String) {
+			notifications(first: $first, after: $after) {
+				nodes {
+					id
+					type
+					readAt
+					snoozedUntilAt
+					createdAt
+					actor {
+						id
+						name
+					}
+					... on IssueNotification {
+						issue {
+							id
+							identifier
+							title
+						}
+						comment {
+							id
+							body
+						}
+					}
+				}
+				pageInfo {
+					hasNextPage
+					hasPreviousPage
+					startCursor
+					endCursor
+				}
+			}
+		}
+	`
+
+	variables := opts.variables()
+
+	var response NotificationsResponse
+	err := c.executeWithRetry(ctx, func() error {
+		return c.executeGraphQL(ctx, query, variables, &response)
+	})
+
+	if err != nil {
+		c.debugLog.LogError("Failed to fetch notifications", err)
+		return nil, err
+	}
+
+	return &Page[Notification]{
+		Nodes:    response.Notifications.Nodes,
+		PageInfo: response.Notifications.PageInfo,
+	}, nil
+}
+
+// UpdateNotification marks a notification read or unread, or snoozes it
+func (c *Client) UpdateNotification(ctx context.Context, id string, input UpdateNotificationInput) error {
+	mutation := `
+		mutation UpdateNotification($id: String!, $input: NotificationUpdateInput!) {
+			notificationUpdate(id: $id, input: $input) {
+				success
+			}
+		}
+	`
+
+	variables := map[string]interface{}{
+		"id":    id,
+		"input": input,
+	}
+
+	var response struct {
+		NotificationUpdate struct {
+			Success bool `json:"success"`
+		} `json:"notificationUpdate"`
+	}
+
+	err := c.executeWithRetry(ctx, func() error {
+		return c.executeGraphQL(ctx, mutation, variables, &response)
+	})
+
+	if err != nil {
+		c.debugLog.LogError("Failed to update notification", err)
+		return err
+	}
+
+	if !response.NotificationUpdate.Success {
+		return NewLinearError(ErrorTypeAPI, "failed to update notification", 200)
+	}
+
+	return nil
+}
@@ -0,0 +1,49 @@
+package linear
+
+import "encoding/json"
+
+// Optional is an input field with three states: unset, in which case it is
+// left out of the input and Linear leaves the field as it is; set to a
+// value; or null, which clears the field. The zero value is unset.
+//
+// Fields of this type are tagged omitzero so that unset ones are left out
+// when the input is marshaled.
+type Optional[T any] struct {
+	value T
+	set   bool
+	null  bool
+}
+
+// Set returns an Optional set to value
+func Set[T any](value T) Optional[T] {
+	return Optional[T]{value: value, set: true}
+}
+
+// Null returns an Optional that clears the field
+func Null[T any]() Optional[T] {
+	return Optional[T]{null: true}
+}
+
+// Value returns the value and whether the Optional is set to one
+func (o Optional[T]) Value() (T, bool) {
+	return o.value, o.set
+}
+
+// IsNull reports whether the Optional clears the field
+func (o Optional[T]) IsNull() bool {
+	return o.null
+}
+
+// IsZero reports whether the Optional is unset. It is used by the omitzero
+// option of encoding/json.
+func (o Optional[T]) IsZero() bool {
+	return !o.set && !o.null
+}
+
+// MarshalJSON encodes the value, or null if the Optional is null or unset
+func (o Optional[T]) MarshalJSON() ([]byte, error) {
+	if !o.set {
+		return []byte("null"), nil
+	}
+	return json.Marshal(o.value)
+}
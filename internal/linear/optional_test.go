@@ -0,0 +1,67 @@
+package linear
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestOptional(t *testing.T) {
+	type input struct {
+		Name     Optional[string]   `json:"name,omitzero"`
+		Priority Optional[int]      `json:"priority,omitzero"`
+		LabelIDs Optional[[]string] `json:"labelIds,omitzero"`
+	}
+
+	tests := []struct {
+		name  string
+		input input
+		want  string
+	}{
+		{"unset fields are left out", input{}, `{}`},
+		{"set fields", input{Name: Set("Bug"), Priority: Set(2), LabelIDs: Set([]string{"a", "b"})}, `{"name":"Bug","priority":2,"labelIds":["a","b"]}`},
+		{"zero values are sent", input{Name: Set(""), Priority: Set(0), LabelIDs: Set([]string{})}, `{"name":"","priority":0,"labelIds":[]}`},
+		{"null fields are cleared", input{Name: Null[string](), Priority: Null[int](), LabelIDs: Null[[]string]()}, `{"name":null,"priority":null,"labelIds":null}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := json.Marshal(tt.input)
+			if err != nil {
+				t.Fatalf("Marshal failed: %v", err)
+			}
+			if string(data) != tt.want {
+				t.Errorf("Marshal = %s, want %s", data, tt.want)
+			}
+		})
+	}
+}
+
+func TestOptionalState(t *testing.T) {
+	tests := []struct {
+		name     string
+		optional Optional[int]
+		value    int
+		set      bool
+		null     bool
+	}{
+		{"unset", Optional[int]{}, 0, false, false},
+		{"set", Set(3), 3, true, false},
+		{"set to zero", Set(0), 0, true, false},
+		{"null", Null[int](), 0, false, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			value, set := tt.optional.Value()
+			if value != tt.value || set != tt.set {
+				t.Errorf("Value() = %d, %v, want %d, %v", value, set, tt.value, tt.set)
+			}
+			if tt.optional.IsNull() != tt.null {
+				t.Errorf("IsNull() = %v, want %v", tt.optional.IsNull(), tt.null)
+			}
+			if zero := !tt.set && !tt.null; tt.optional.IsZero() != zero {
+				t.Errorf("IsZero() = %v, want %v", tt.optional.IsZero(), zero)
+			}
+		})
+	}
+}
@@ -0,0 +1,116 @@
+package linear
+
+import "context"
+
+const (
+	// DefaultPageSize is the page size used when none is given
+	DefaultPageSize = 50
+	// MaxPageSize is the largest page Linear will return for a connection
+	MaxPageSize = 250
+	// DefaultFetchAllLimit caps how many nodes FetchAll collects when the
+	// caller does not give a limit
+	DefaultFetchAllLimit = 5000
+)
+
+// PageOptions selects a page of a paginated connection
+type PageOptions struct {
+	First int
+	After string
+}
+
+// variables returns the GraphQL variables for the page options
+func (o PageOptions) variables() map[string]interface{} {
+	first := o.First
+	if first <= 0 {
+		first = DefaultPageSize
+	}
+	if first > MaxPageSize {
+		first = MaxPageSize
+	}
+
+	variables := map[string]interface{}{
+		"first": first,
+	}
+	if o.After != "" {
+		variables["after"] = o.After
+	}
+	return variables
+}
+
+// Page is a single page of a paginated connection
+type Page[T any] struct {
+	Nodes    []T
+	PageInfo PageInfo
+}
+
+// PageFetcher fetches one page of a connection
+type PageFetcher[T any] func(ctx context.Context, opts PageOptions) (*Page[T], error)
+
+// Iterator walks a paginated connection one page at a time
+type Iterator[T any] struct {
+	fetch    PageFetcher[T]
+	pageSize int
+	cursor   string
+	done     bool
+}
+
+// NewIterator creates an iterator that fetches pages of the given size
+func NewIterator[T any](pageSize int, fetch PageFetcher[T]) *Iterator[T] {
+	return &Iterator[T]{
+		fetch:    fetch,
+		pageSize: pageSize,
+	}
+}
+
+// HasNext reports whether there may be more pages to fetch
+func (it *Iterator[T]) HasNext() bool {
+	return !it.done
+}
+
+// Cursor returns the cursor of the last fetched page, which can be passed
+// as PageOptions.After to resume from there
+func (it *Iterator[T]) Cursor() string {
+	return it.cursor
+}
+
+// Next fetches the next page. It returns nil once the connection is
+// exhausted.
+func (it *Iterator[T]) Next(ctx context.Context) ([]T, error) {
+	if it.done {
+		return nil, nil
+	}
+
+	page, err := it.fetch(ctx, PageOptions{First: it.pageSize, After: it.cursor})
+	if err != nil {
+		return nil, err
+	}
+
+	it.cursor = page.PageInfo.EndCursor
+	if !page.PageInfo.HasNextPage || it.cursor == "" {
+		it.done = true
+	}
+
+	return page.Nodes, nil
+}
+
+// FetchAll collects the remaining pages of an iterator, stopping once limit
+// nodes have been collected. A limit of zero uses DefaultFetchAllLimit.
+func FetchAll[T any](ctx context.Context, it *Iterator[T], limit int) ([]T, error) {
+	if limit <= 0 {
+		limit = DefaultFetchAllLimit
+	}
+
+	var all []T
+	for it.HasNext() && len(all) < limit {
+		nodes, err := it.Next(ctx)
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, nodes...)
+	}
+
+	if len(all) > limit {
+		all = all[:limit]
+	}
+	return all, nil
+}
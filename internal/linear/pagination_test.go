@@ -0,0 +1,128 @@
+package linear
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"testing"
+)
+
+// fakeConnection is a connection of the numbers from 0 to size, paged the
+// way Linear pages them: the cursor of a page is its last number, and a
+// page is no larger than MaxPageSize. The page starting at failAt, if set,
+// fails.
+type fakeConnection struct {
+	size   int
+	failAt int
+
+	fetches int
+	sizes   []int
+}
+
+var errPage = errors.New("page failed")
+
+func (c *fakeConnection) fetch(_ context.Context, opts PageOptions) (*Page[int], error) {
+	c.fetches++
+	first := opts.variables()["first"].(int)
+	c.sizes = append(c.sizes, first)
+
+	start := 0
+	if opts.After != "" {
+		after, err := strconv.Atoi(opts.After)
+		if err != nil {
+			return nil, err
+		}
+		start = after + 1
+	}
+	if c.failAt > 0 && start == c.failAt {
+		return nil, errPage
+	}
+
+	page := &Page[int]{}
+	for n := start; n < min(start+first, c.size); n++ {
+		page.Nodes = append(page.Nodes, n)
+	}
+	if len(page.Nodes) > 0 {
+		page.PageInfo.EndCursor = strconv.Itoa(page.Nodes[len(page.Nodes)-1])
+	}
+	page.PageInfo.HasNextPage = start+first < c.size
+	return page, nil
+}
+
+func TestFetchAll(t *testing.T) {
+	tests := []struct {
+		name     string
+		size     int
+		failAt   int
+		pageSize int
+		limit    int
+		want     int
+		fetches  int
+		pageSent int
+		err      error
+	}{
+		{name: "limit of zero defaults to 5000", size: 6000, pageSize: MaxPageSize, want: DefaultFetchAllLimit, fetches: 20, pageSent: MaxPageSize},
+		{name: "page size clamped to the largest page", size: 600, pageSize: 1000, want: 600, fetches: 3, pageSent: MaxPageSize},
+		{name: "page size of zero uses the default", size: 120, want: 120, fetches: 3, pageSent: DefaultPageSize},
+		{name: "limit cuts the last page", size: 1000, pageSize: 50, limit: 120, want: 120, fetches: 3, pageSent: 50},
+		{name: "stops at the last page", size: 30, pageSize: 50, limit: 100, want: 30, fetches: 1, pageSent: 50},
+		{name: "stops at an exactly full last page", size: 100, pageSize: 50, want: 100, fetches: 2, pageSent: 50},
+		{name: "error on a later page", size: 200, failAt: 100, pageSize: 50, fetches: 3, pageSent: 50, err: errPage},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conn := &fakeConnection{size: tt.size, failAt: tt.failAt}
+			nodes, err := FetchAll(context.Background(), NewIterator(tt.pageSize, conn.fetch), tt.limit)
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("FetchAll error = %v, want %v", err, tt.err)
+			}
+			if len(nodes) != tt.want {
+				t.Errorf("FetchAll returned %d nodes, want %d", len(nodes), tt.want)
+			}
+			for i, n := range nodes {
+				if n != i {
+					t.Fatalf("node %d = %d, want the nodes in order", i, n)
+				}
+			}
+			if conn.fetches != tt.fetches {
+				t.Errorf("fetched %d pages, want %d", conn.fetches, tt.fetches)
+			}
+			for _, size := range conn.sizes {
+				if size != tt.pageSent {
+					t.Errorf("asked for pages of %d, want %d", size, tt.pageSent)
+					break
+				}
+			}
+		})
+	}
+}
+
+func TestIterator(t *testing.T) {
+	conn := &fakeConnection{size: 3}
+	it := NewIterator(2, conn.fetch)
+	ctx := context.Background()
+
+	steps := []struct {
+		want    int
+		cursor  string
+		hasNext bool
+	}{
+		{want: 2, cursor: "1", hasNext: true},
+		{want: 1, cursor: "2", hasNext: false},
+		// An exhausted iterator fetches nothing more
+		{want: 0, cursor: "2", hasNext: false},
+	}
+	for i, step := range steps {
+		nodes, err := it.Next(ctx)
+		if err != nil {
+			t.Fatalf("step %d: Next failed: %v", i, err)
+		}
+		if len(nodes) != step.want || it.Cursor() != step.cursor || it.HasNext() != step.hasNext {
+			t.Errorf("step %d: %d nodes, cursor %q, HasNext %v, want %d, %q, %v", i, len(nodes), it.Cursor(), it.HasNext(), step.want, step.cursor, step.hasNext)
+		}
+	}
+	if conn.fetches != 2 {
+		t.Errorf("fetched %d pages, want 2", conn.fetches)
+	}
+}
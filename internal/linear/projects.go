@@ -0,0 +1,166 @@
+package linear
+
+import "context"
+
+// Project health values, from the project's latest update
+const (
+	HealthOnTrack  = "onTrack"
+	HealthAtRisk   = "atRisk"
+	HealthOffTrack = "offTrack"
+)
+
+// ProjectDetails is a project with the people working on it, its
+// milestones and its health. Health is nil until a project update has been
+// posted.
+type ProjectDetails struct {
+	Project
+	Health  *string `json:"health"`
+	Lead    *User   `json:"lead"`
+	Members struct {
+		Nodes []User `json:"nodes"`
+	} `json:"members"`
+	ProjectMilestones struct {
+		Nodes []ProjectMilestone `json:"nodes"`
+	} `json:"projectMilestones"`
+}
+
+// ProjectMilestone is a stage of a project
+type ProjectMilestone struct {
+	ID         string  `json:"id"`
+	Name       string  `json:"name"`
+	TargetDate *string `json:"targetDate"`
+}
+
+type ProjectIssuesResponse struct {
+	Project struct {
+		Issues struct {
+			Nodes    []Issue  `json:"nodes"`
+			PageInfo PageInfo `json:"pageInfo"`
+		} `json:"issues"`
+	} `json:"project"`
+}
+
+// GetProjectDetails retrieves a project with its lead, members, milestones
+// and health
+func (c *Client) GetProjectDetails(ctx context.Context, projectID string) (*ProjectDetails, error) {
+	c.debugLog.LogInfo("Fetching project %s", projectID)
+	query := `
+		query GetProject($id: String!) {
+			project(id: $id) {
+				id
+				name
+				description
+				state
+				progress
+				startDate
+				targetDate
+				health
+				lead {
+					id
+					name
+				}
+				members(first: 100) {
+					nodes {
+						id
+						name
+					}
+				}
+				projectMilestones(first: 100) {
+					nodes {
+						id
+						name
+						targetDate
+					}
+				}
+			}
+		}
+	`
+
+	variables := map[string]interface{}{
+		"id": projectID,
+	}
+
+	var response struct {
+		Project ProjectDetails `json:"project"`
+	}
+
+	err := c.executeWithRetry(ctx, func() error {
+		return c.executeGraphQL(ctx, query, variables, &response)
+	})
+
+	if err != nil {
+		c.debugLog.LogError("Failed to fetch project", err)
+		return nil, err
+	}
+
+	return &response.Project, nil
+}
+
+// GetProjectIssueStates retrieves the workflow state of each issue of a
+// project, following pagination
+func (c *Client) GetProjectIssueStates(ctx context.Context, projectID string) ([]IssueState, error) {
+	issues, err := FetchAll(ctx, c.ProjectIssueStatesIterator(projectID, MaxPageSize), 0)
+	if err != nil {
+		return nil, err
+	}
+
+	states := make([]IssueState, len(issues))
+	for i, issue := range issues {
+		states[i] = issue.State
+	}
+	return states, nil
+}
+
+// ProjectIssueStatesIterator returns an iterator over the issues of a
+// project, with only their workflow state fetched
+func (c *Client) ProjectIssueStatesIterator(projectID string, pageSize int) *Iterator[Issue] {
+	return NewIterator(pageSize, func(ctx context.Context, opts PageOptions) (*Page[Issue], error) {
+		return c.GetProjectIssueStatesPage(ctx, projectID, opts)
+	})
+}
+
+// GetProjectIssueStatesPage retrieves a single page of the issues of a
+// project, with only their workflow state fetched
+func (c *Client) GetProjectIssueStatesPage(ctx context.Context, projectID string, opts PageOptions) (*Page[Issue], error) {
+	query := `
+		query GetProjectIssueStates($id: String!, $first: Int!, $after: String) {
+			project(id: $id) {
+				issues(first: $first, after: $after) {
+					nodes {
+						state {
+							id
+							name
+							type
+							color
+							position
+						}
+					}
+					pageInfo {
+						hasNextPage
+						hasPreviousPage
+						startCursor
+						endCursor
+					}
+				}
+			}
+		}
+	`
+
+	variables := opts.variables()
+	variables["id"] = projectID
+
+	var response ProjectIssuesResponse
+	err := c.executeWithRetry(ctx, func() error {
+		return c.executeGraphQL(ctx, query, variables, &response)
+	})
+
+	if err != nil {
+		c.debugLog.LogError("Failed to fetch project issues", err)
+		return nil, err
+	}
+
+	return &Page[Issue]{
+		Nodes:    response.Project.Issues.Nodes,
+		PageInfo: response.Project.Issues.PageInfo,
+	}, nil
+}
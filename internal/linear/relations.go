@@ -0,0 +1,148 @@
+package linear
+
+import "context"
+
+// Relation types of an IssueRelation. A relation is stored once, on the
+// issue it was created from, so an issue's inverse relations hold those
+// pointing at it: an issue is blocked by the issues of its inverse blocks
+// relations.
+const (
+	RelationBlocks    = "blocks"
+	RelationDuplicate = "duplicate"
+	RelationRelated   = "related"
+)
+
+// IssueRelation is a relation from Issue to RelatedIssue
+type IssueRelation struct {
+	ID           string `json:"id"`
+	Type         string `json:"type"`
+	Issue        *Issue `json:"issue"`
+	RelatedIssue *Issue `json:"relatedIssue"`
+}
+
+// IssueRelations are the issues an issue is linked to: its parent, its
+// sub-issues and its relations in either direction
+type IssueRelations struct {
+	Parent   *Issue `json:"parent"`
+	Children struct {
+		Nodes []Issue `json:"nodes"`
+	} `json:"children"`
+	Relations struct {
+		Nodes []IssueRelation `json:"nodes"`
+	} `json:"relations"`
+	InverseRelations struct {
+		Nodes []IssueRelation `json:"nodes"`
+	} `json:"inverseRelations"`
+}
+
+// CreateIssueRelationInput is the input for linking two issues
+type CreateIssueRelationInput struct {
+	IssueID        string `json:"issueId"`
+	RelatedIssueID string `json:"relatedIssueId"`
+	Type           string `json:"type"`
+}
+
+// GetIssueRelations retrieves the parent, sub-issues and relations of an
+// issue
+func (c *Client) GetIssueRelations(ctx context.Context, issueID string) (*IssueRelations, error) {
+	c.debugLog.LogInfo("Fetching relations of issue %s", issueID)
+	query := `
+		query GetIssueRelations($id: String!) {
+			issue(id: $id) {
+				parent {
+					...LinkedIssue
+				}
+				children(first: 100) {
+					nodes {
+						...LinkedIssue
+					}
+				}
+				relations(first: 100) {
+					nodes {
+						id
+						type
+						relatedIssue {
+							...LinkedIssue
+						}
+					}
+				}
+				inverseRelations(first: 100) {
+					nodes {
+						id
+						type
+						issue {
+							...LinkedIssue
+						}
+					}
+				}
+			}
+		}
+
+		fragment LinkedIssue on Issue {
+			id
+			identifier
+			title
+			state {
+				id
+				name
+				type
+				color
+			}
+		}
+	`
+
+	variables := map[string]interface{}{
+		"id": issueID,
+	}
+
+	var response struct {
+		Issue IssueRelations `json:"issue"`
+	}
+
+	err := c.executeWithRetry(ctx, func() error {
+		return c.executeGraphQL(ctx, query, variables, &response)
+	})
+
+	if err != nil {
+		c.debugLog.LogError("Failed to fetch issue relations", err)
+		return nil, err
+	}
+
+	return &response.Issue, nil
+}
+
+// CreateIssueRelation links two issues
+func (c *Client) CreateIssueRelation(ctx context.Context, input CreateIssueRelationInput) error {
+	mutation := `
+		mutation CreateIssueRelation($input: IssueRelationCreateInput!) {
+			issueRelationCreate(input: $input) {
+				success
+			}
+		}
+	`
+
+	variables := map[string]interface{}{
+		"input": input,
+	}
+
+	var response struct {
+		IssueRelationCreate struct {
+			Success bool `json:"success"`
+		} `json:"issueRelationCreate"`
+	}
+
+	err := c.executeWithRetry(ctx, func() error {
+		return c.executeGraphQL(ctx, mutation, variables, &response)
+	})
+
+	if err != nil {
+		c.debugLog.LogError("Failed to create issue relation", err)
+		return err
+	}
+
+	if !response.IssueRelationCreate.Success {
+		return NewLinearError(ErrorTypeAPI, "failed to link issues", 200)
+	}
+
+	return nil
+}
@@ -0,0 +1,186 @@
+package linear
+
+import (
+	"context"
+	"time"
+)
+
+// SyncedIssue is an issue fetched to bring a local copy up to date.
+// ArchivedAt is set once the issue has been archived or deleted, since
+// deleted issues are archived while they are in the trash.
+type SyncedIssue struct {
+	Issue
+	ArchivedAt *time.Time `json:"archivedAt"`
+}
+
+// SyncedProject is a project fetched to bring a local copy up to date.
+// Like issues, archived and deleted projects have ArchivedAt set.
+type SyncedProject struct {
+	Project
+	ArchivedAt *time.Time `json:"archivedAt"`
+}
+
+type SyncedIssuesResponse struct {
+	Issues struct {
+		Nodes    []SyncedIssue `json:"nodes"`
+		PageInfo PageInfo      `json:"pageInfo"`
+	} `json:"issues"`
+}
+
+type SyncedProjectsResponse struct {
+	Projects struct {
+		Nodes    []SyncedProject `json:"nodes"`
+		PageInfo PageInfo        `json:"pageInfo"`
+	} `json:"projects"`
+}
+
+// GetSyncedIssues retrieves up to limit issues matching filter, most
+// recently updated first, following pagination. With includeArchived,
+// archived and deleted issues are returned too.
+func (c *Client) GetSyncedIssues(ctx context.Context, filter IssueFilter, includeArchived bool, limit int) ([]SyncedIssue, error) {
+	c.debugLog.LogInfo("Syncing issues (updated after: %s, archived: %t)", filter.UpdatedAfter.Format(time.RFC3339), includeArchived)
+
+	issues, err := FetchAll(ctx, c.SyncedIssuesIterator(filter, includeArchived, MaxPageSize), limit)
+	if err != nil {
+		return nil, err
+	}
+
+	c.debugLog.LogInfo("Successfully synced %d issues", len(issues))
+	return issues, nil
+}
+
+// SyncedIssuesIterator returns an iterator over the issues matching filter
+// for a sync
+func (c *Client) SyncedIssuesIterator(filter IssueFilter, includeArchived bool, pageSize int) *Iterator[SyncedIssue] {
+	return NewIterator(pageSize, func(ctx context.Context, opts PageOptions) (*Page[SyncedIssue], error) {
+		return c.GetSyncedIssuesPage(ctx, filter, includeArchived, opts)
+	})
+}
+
+// GetSyncedIssuesPage retrieves a single page of the issues matching
+// filter for a sync
+func (c *Client) GetSyncedIssuesPage(ctx context.Context, filter IssueFilter, includeArchived bool, opts PageOptions) (*Page[SyncedIssue], error) {
+	if err := filter.Validate(); err != nil {
+		return nil, err
+	}
+
+	query := `
+		query SyncIssues($filter: IssueFilter, $includeArchived: Boolean, $first: Int!, $after: String) {
+			issues(
+				filter: $filter,
+				includeArchived: $includeArchived,
+				first: $first,
+				after: $after,
+				orderBy: updatedAt
+			) {
+				nodes {
+					...IssueFields
+					archivedAt
+				}
+				pageInfo {
+					hasNextPage
+					hasPreviousPage
+					startCursor
+					endCursor
+				}
+			}
+		}
+	` + issueFields
+
+	variables := opts.variables()
+	variables["filter"] = filter.GraphQL()
+	variables["includeArchived"] = includeArchived
+
+	var response SyncedIssuesResponse
+	err := c.executeWithRetry(ctx, func() error {
+		return c.executeGraphQL(ctx, query, variables, &response)
+	})
+
+	if err != nil {
+		c.debugLog.LogError("Failed to sync issues", err)
+		return nil, err
+	}
+
+	return &Page[SyncedIssue]{
+		Nodes:    response.Issues.Nodes,
+		PageInfo: response.Issues.PageInfo,
+	}, nil
+}
+
+// GetSyncedProjects retrieves the projects updated at or after since, or
+// every project if since is zero, following pagination. Archived and
+// deleted projects are returned too, unless since is zero.
+func (c *Client) GetSyncedProjects(ctx context.Context, since time.Time) ([]SyncedProject, error) {
+	c.debugLog.LogInfo("Syncing projects (updated after: %s)", since.Format(time.RFC3339))
+
+	projects, err := FetchAll(ctx, c.SyncedProjectsIterator(since, MaxPageSize), 0)
+	if err != nil {
+		return nil, err
+	}
+
+	c.debugLog.LogInfo("Successfully synced %d projects", len(projects))
+	return projects, nil
+}
+
+// SyncedProjectsIterator returns an iterator over the projects updated at
+// or after since
+func (c *Client) SyncedProjectsIterator(since time.Time, pageSize int) *Iterator[SyncedProject] {
+	return NewIterator(pageSize, func(ctx context.Context, opts PageOptions) (*Page[SyncedProject], error) {
+		return c.GetSyncedProjectsPage(ctx, since, opts)
+	})
+}
+
+// GetSyncedProjectsPage retrieves a single page of the projects updated at
+// or after since
+func (c *Client) GetSyncedProjectsPage(ctx context.Context, since time.Time, opts PageOptions) (*Page[SyncedProject], error) {
+	query := `
+		query SyncProjects($filter: ProjectFilter, $includeArchived: Boolean, $first: Int!, $after: String) {
+			projects(
+				filter: $filter,
+				includeArchived: $includeArchived,
+				first: $first,
+				after: $after,
+				orderBy: updatedAt
+			) {
+				nodes {
+					id
+					name
+					description
+					state
+					progress
+					startDate
+					targetDate
+					updatedAt
+					archivedAt
+				}
+				pageInfo {
+					hasNextPage
+					hasPreviousPage
+					startCursor
+					endCursor
+				}
+			}
+		}
+	`
+
+	variables := opts.variables()
+	if !since.IsZero() {
+		variables["filter"] = map[string]interface{}{"updatedAt": dateRange(since, time.Time{})}
+		variables["includeArchived"] = true
+	}
+
+	var response SyncedProjectsResponse
+	err := c.executeWithRetry(ctx, func() error {
+		return c.executeGraphQL(ctx, query, variables, &response)
+	})
+
+	if err != nil {
+		c.debugLog.LogError("Failed to sync projects", err)
+		return nil, err
+	}
+
+	return &Page[SyncedProject]{
+		Nodes:    response.Projects.Nodes,
+		PageInfo: response.Projects.PageInfo,
+	}, nil
+}
@@ -3,24 +3,29 @@ package linear
 import "time"
 
 type Issue struct {
-	ID          string     `json:"id"`
-	Identifier  string     `json:"identifier"`
-	Title       string     `json:"title"`
-	Description string     `json:"description"`
-	State       IssueState `json:"state"`
-	Priority    int        `json:"priority"`
-	Assignee    *User      `json:"assignee"`
-	Team        *Team      `json:"team"`
-	Project     *Project   `json:"project"`
-	CreatedAt   time.Time  `json:"createdAt"`
-	UpdatedAt   time.Time  `json:"updatedAt"`
+	ID          string               `json:"id"`
+	Identifier  string               `json:"identifier"`
+	Title       string               `json:"title"`
+	Description string               `json:"description"`
+	State       IssueState           `json:"state"`
+	Priority    int                  `json:"priority"`
+	Assignee    *User                `json:"assignee"`
+	Creator     *User                `json:"creator"`
+	Team        *Team                `json:"team"`
+	Project     *Project             `json:"project"`
+	Cycle       *Cycle               `json:"cycle"`
+	Parent      *Issue               `json:"parent"`
+	Labels      IssueLabelConnection `json:"labels"`
+	CreatedAt   time.Time            `json:"createdAt"`
+	UpdatedAt   time.Time            `json:"updatedAt"`
 }
 
 type IssueState struct {
-	ID    string `json:"id"`
-	Name  string `json:"name"`
-	Type  string `json:"type"`
-	Color string `json:"color"`
+	ID       string  `json:"id"`
+	Name     string  `json:"name"`
+	Type     string  `json:"type"`
+	Color    string  `json:"color"`
+	Position float64 `json:"position"`
 }
 
 type Project struct {
@@ -31,6 +36,21 @@ type Project struct {
 	Progress    float64 `json:"progress"`
 	StartDate   *string `json:"startDate"`
 	TargetDate  *string `json:"targetDate"`
+
+	// UpdatedAt is only fetched with the list of projects
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+type IssueLabel struct {
+	ID    string `json:"id"`
+	Name  string `json:"name"`
+	Color string `json:"color"`
+	Team  *Team  `json:"team"`
+}
+
+// IssueLabelConnection holds the labels of an issue
+type IssueLabelConnection struct {
+	Nodes []IssueLabel `json:"nodes"`
 }
 
 type Team struct {
@@ -41,10 +61,11 @@ type Team struct {
 }
 
 type User struct {
-	ID        string `json:"id"`
-	Name      string `json:"name"`
-	Email     string `json:"email"`
-	AvatarURL string `json:"avatarUrl"`
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	DisplayName string `json:"displayName"`
+	Email       string `json:"email"`
+	AvatarURL   string `json:"avatarUrl"`
 }
 
 type Comment struct {
@@ -52,27 +73,38 @@ type Comment struct {
 	Body      string    `json:"body"`
 	User      *User     `json:"user"`
 	Issue     *Issue    `json:"issue"`
+	Parent    *Comment  `json:"parent"`
 	CreatedAt time.Time `json:"createdAt"`
 	UpdatedAt time.Time `json:"updatedAt"`
 }
 
+// CreateIssueInput is the input for creating an issue. Title and TeamID
+// are required; the other fields are left to Linear's defaults when unset.
+// ID, a UUID, makes Linear refuse to create the issue a second time.
 type CreateIssueInput struct {
-	Title       string `json:"title"`
-	Description string `json:"description,omitempty"`
-	TeamID      string `json:"teamId"`
-	Priority    int    `json:"priority,omitempty"`
-	AssigneeID  string `json:"assigneeId,omitempty"`
-	ProjectID   string `json:"projectId,omitempty"`
-	StateID     string `json:"stateId,omitempty"`
+	ID          Optional[string]   `json:"id,omitzero"`
+	Title       string             `json:"title"`
+	Description Optional[string]   `json:"description,omitzero"`
+	TeamID      string             `json:"teamId"`
+	Priority    Optional[int]      `json:"priority,omitzero"`
+	AssigneeID  Optional[string]   `json:"assigneeId,omitzero"`
+	ProjectID   Optional[string]   `json:"projectId,omitzero"`
+	StateID     Optional[string]   `json:"stateId,omitzero"`
+	LabelIDs    Optional[[]string] `json:"labelIds,omitzero"`
+	ParentID    Optional[string]   `json:"parentId,omitzero"`
 }
 
+// UpdateIssueInput is the input for updating an issue. Unset fields are
+// left as they are; null ones are cleared.
 type UpdateIssueInput struct {
-	Title       string `json:"title,omitempty"`
-	Description string `json:"description,omitempty"`
-	Priority    int    `json:"priority,omitempty"`
-	AssigneeID  string `json:"assigneeId,omitempty"`
-	ProjectID   string `json:"projectId,omitempty"`
-	StateID     string `json:"stateId,omitempty"`
+	Title       Optional[string]   `json:"title,omitzero"`
+	Description Optional[string]   `json:"description,omitzero"`
+	Priority    Optional[int]      `json:"priority,omitzero"`
+	AssigneeID  Optional[string]   `json:"assigneeId,omitzero"`
+	ProjectID   Optional[string]   `json:"projectId,omitzero"`
+	StateID     Optional[string]   `json:"stateId,omitzero"`
+	CycleID     Optional[string]   `json:"cycleId,omitzero"`
+	LabelIDs    Optional[[]string] `json:"labelIds,omitzero"`
 }
 
 type IssuesResponse struct {
@@ -82,6 +114,29 @@ type IssuesResponse struct {
 	} `json:"issues"`
 }
 
+type SearchIssuesResponse struct {
+	SearchIssues struct {
+		Nodes    []Issue  `json:"nodes"`
+		PageInfo PageInfo `json:"pageInfo"`
+	} `json:"searchIssues"`
+}
+
+type IssueCommentsResponse struct {
+	Issue struct {
+		Comments struct {
+			Nodes    []Comment `json:"nodes"`
+			PageInfo PageInfo  `json:"pageInfo"`
+		} `json:"comments"`
+	} `json:"issue"`
+}
+
+type IssueLabelsResponse struct {
+	IssueLabels struct {
+		Nodes    []IssueLabel `json:"nodes"`
+		PageInfo PageInfo     `json:"pageInfo"`
+	} `json:"issueLabels"`
+}
+
 type ProjectsResponse struct {
 	Projects struct {
 		Nodes    []Project `json:"nodes"`
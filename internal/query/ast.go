@@ -0,0 +1,137 @@
+// Package query parses the compact filter syntax used by the filter bar,
+// such as `assignee:me status:"In Progress" priority:>=high updated:<7d`,
+// and compiles it into Linear issue filters.
+package query
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Span is a byte range in the query text
+type Span struct {
+	Start int
+	End   int
+}
+
+// Error is a parse or compile error located at a span of the query text
+type Error struct {
+	Span    Span
+	Message string
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("column %d: %s", e.Span.Start+1, e.Message)
+}
+
+func errorAt(span Span, format string, args ...interface{}) *Error {
+	return &Error{Span: span, Message: fmt.Sprintf(format, args...)}
+}
+
+// Field is a filterable issue attribute
+type Field string
+
+const (
+	FieldTeam     Field = "team"
+	FieldAssignee Field = "assignee"
+	FieldStatus   Field = "status"
+	FieldState    Field = "state"
+	FieldPriority Field = "priority"
+	FieldLabel    Field = "label"
+	FieldProject  Field = "project"
+	FieldCycle    Field = "cycle"
+	FieldCreated  Field = "created"
+	FieldUpdated  Field = "updated"
+	FieldID       Field = "id"
+)
+
+// fields maps the names accepted in queries to fields
+var fields = map[string]Field{
+	"team":     FieldTeam,
+	"assignee": FieldAssignee,
+	"status":   FieldStatus,
+	"state":    FieldState,
+	"priority": FieldPriority,
+	"prio":     FieldPriority,
+	"label":    FieldLabel,
+	"labels":   FieldLabel,
+	"project":  FieldProject,
+	"cycle":    FieldCycle,
+	"created":  FieldCreated,
+	"updated":  FieldUpdated,
+	"id":       FieldID,
+}
+
+// Op is the comparison of a field term
+type Op string
+
+const (
+	OpEq  Op = "="
+	OpLt  Op = "<"
+	OpLte Op = "<="
+	OpGt  Op = ">"
+	OpGte Op = ">="
+)
+
+// Query is a parsed filter query. All terms must match.
+type Query struct {
+	Terms []Term
+	Text  []TextTerm
+}
+
+// IsEmpty reports whether the query has no terms
+func (q Query) IsEmpty() bool {
+	return len(q.Terms) == 0 && len(q.Text) == 0
+}
+
+// SearchText returns the free text the query searches for, leaving out
+// negated text
+func (q Query) SearchText() string {
+	var words []string
+	for _, text := range q.Text {
+		if !text.Negated {
+			words = append(words, text.Text)
+		}
+	}
+	return strings.Join(words, " ")
+}
+
+// Term is a `field:value` term. A term with several comma separated values
+// matches any of them.
+type Term struct {
+	Field     Field
+	Op        Op
+	Values    []Value
+	Negated   bool
+	Span      Span
+	FieldSpan Span
+}
+
+// Value is a single term value. Besides the raw text, values of priority
+// and date fields carry their parsed form.
+type Value struct {
+	Raw  string
+	Span Span
+
+	// Priority is the Linear priority number of a priority value
+	Priority int
+
+	// Cycle is the number of a cycle value, or zero for a relative cycle
+	Cycle int
+
+	// Date values are either an absolute Time or, when Relative is set, an
+	// Age relative to the time the query is compiled
+	Time     time.Time
+	Age      time.Duration
+	Relative bool
+}
+
+// TextTerm is free text. It is searched for with Linear's full-text search
+// and fuzzy matched against loaded issues; negated text excludes issues
+// whose title or description contains it.
+type TextTerm struct {
+	Text    string
+	Negated bool
+	Span    Span
+}
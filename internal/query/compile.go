@@ -0,0 +1,308 @@
+package query
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/linear-tui/linear-tui/internal/linear"
+)
+
+// Resolver looks up workspace entities referenced by name in a query
+type Resolver interface {
+	TeamID(name string) (string, bool)
+	UserID(name string) (string, bool)
+	ProjectID(name string) (string, bool)
+}
+
+// Compiled is a query split into a filter Linear evaluates server-side and
+// the remaining terms, which are matched against loaded issues. Search is
+// the query's free text, for Linear's full-text search.
+type Compiled struct {
+	Filter   linear.IssueFilter
+	Residual Query
+	Search   string
+}
+
+// Compile narrows base with the terms of a query. Terms Linear can evaluate
+// go into the filter; the rest are kept as the residual query. The state
+// types of base are dropped when the query filters on status or state, and
+// its teams are replaced when it filters on team.
+func Compile(q Query, base linear.IssueFilter, resolver Resolver, now time.Time) (Compiled, error) {
+	compiled := Compiled{Filter: base}
+	filter := &compiled.Filter
+
+	for _, term := range q.Terms {
+		if term.Field == FieldStatus || term.Field == FieldState {
+			filter.StateTypes = nil
+			break
+		}
+	}
+
+	seen := make(map[Field]bool)
+	for _, term := range q.Terms {
+		term = resolveDates(term, now)
+
+		if !term.Negated && !seen[term.Field] {
+			ok, err := compileTerm(term, filter, resolver)
+			if err != nil {
+				return Compiled{}, err
+			}
+			if ok {
+				seen[term.Field] = true
+				continue
+			}
+		}
+
+		if err := checkMatchable(term); err != nil {
+			return Compiled{}, err
+		}
+		compiled.Residual.Terms = append(compiled.Residual.Terms, term)
+	}
+
+	for _, text := range q.Text {
+		if text.Negated {
+			compiled.Residual.Text = append(compiled.Residual.Text, text)
+		}
+	}
+	compiled.Search = q.SearchText()
+
+	return compiled, nil
+}
+
+// compileTerm adds a term to the filter. It returns false if the filter
+// cannot express the term.
+func compileTerm(term Term, filter *linear.IssueFilter, resolver Resolver) (bool, error) {
+	switch term.Field {
+	case FieldTeam:
+		var ids []string
+		for _, value := range term.Values {
+			id, ok := resolver.TeamID(value.Raw)
+			if !ok {
+				return false, errorAt(value.Span, "unknown team %q", value.Raw)
+			}
+			ids = append(ids, id)
+		}
+		filter.TeamIDs = ids
+		return true, nil
+
+	case FieldAssignee:
+		for _, value := range term.Values {
+			switch strings.ToLower(value.Raw) {
+			case "me":
+				filter.AssignedToMe = true
+			case "none":
+				filter.Unassigned = true
+			default:
+				id, ok := resolver.UserID(value.Raw)
+				if !ok {
+					return false, errorAt(value.Span, "unknown user %q", value.Raw)
+				}
+				filter.AssigneeIDs = append(filter.AssigneeIDs, id)
+			}
+		}
+		return true, nil
+
+	case FieldStatus:
+		for _, value := range term.Values {
+			filter.StateNames = append(filter.StateNames, value.Raw)
+		}
+		return true, nil
+
+	case FieldState:
+		for _, value := range term.Values {
+			filter.StateTypes = append(filter.StateTypes, stateTypes[strings.ToLower(value.Raw)]...)
+		}
+		return true, nil
+
+	case FieldPriority:
+		lowest, highest, ok := priorityRange(allowedPriorities(term))
+		if !ok {
+			return false, nil
+		}
+		filter.MinPriority, filter.MaxPriority = &lowest, &highest
+		return true, nil
+
+	case FieldLabel:
+		for _, value := range term.Values {
+			filter.Labels = append(filter.Labels, value.Raw)
+		}
+		return true, nil
+
+	case FieldProject:
+		if len(term.Values) > 1 {
+			return false, nil
+		}
+		value := term.Values[0]
+		id, ok := resolver.ProjectID(value.Raw)
+		if !ok {
+			return false, errorAt(value.Span, "unknown project %q", value.Raw)
+		}
+		filter.ProjectID = id
+		return true, nil
+
+	case FieldCycle:
+		if len(term.Values) > 1 {
+			return false, nil
+		}
+		value := term.Values[0]
+		if value.Cycle != 0 {
+			filter.CycleNumber = value.Cycle
+		} else {
+			filter.Cycle = cycles[strings.ToLower(value.Raw)]
+		}
+		return true, nil
+
+	case FieldCreated, FieldUpdated:
+		if len(term.Values) > 1 {
+			return false, nil
+		}
+		after, before := dateBounds(term.Op, term.Values[0])
+		if term.Field == FieldCreated {
+			filter.CreatedAfter, filter.CreatedBefore = after, before
+		} else {
+			filter.UpdatedAfter, filter.UpdatedBefore = after, before
+		}
+		return true, nil
+	}
+
+	// Identifiers are matched client-side
+	return false, nil
+}
+
+// checkMatchable reports terms that can be neither sent to Linear nor
+// matched against loaded issues
+func checkMatchable(term Term) error {
+	switch term.Field {
+	case FieldLabel:
+		return errorAt(term.Span, "label can only be used once and cannot be negated")
+	case FieldAssignee:
+		for _, value := range term.Values {
+			if strings.EqualFold(value.Raw, "me") {
+				return errorAt(value.Span, "assignee:me can only be used once and cannot be negated")
+			}
+		}
+	case FieldCycle:
+		// Loaded issues know their cycle's number but not where it falls
+		// relative to the current one
+		for _, value := range term.Values {
+			if cycle := cycles[strings.ToLower(value.Raw)]; cycle != "" && cycle != linear.CycleNone {
+				return errorAt(value.Span, "cycle:%s can only be used once and cannot be negated", value.Raw)
+			}
+		}
+	}
+	return nil
+}
+
+// resolveDates turns relative date values into absolute times
+func resolveDates(term Term, now time.Time) Term {
+	if term.Field != FieldCreated && term.Field != FieldUpdated {
+		return term
+	}
+
+	values := make([]Value, len(term.Values))
+	for i, value := range term.Values {
+		if value.Relative {
+			value.Time = now.Add(-value.Age)
+		}
+		values[i] = value
+	}
+	term.Values = values
+	return term
+}
+
+// dateBounds returns the time range a date term matches. Zero times are
+// unbounded. For ages the comparison is inverted: updated:<7d matches
+// issues updated less than seven days ago.
+func dateBounds(op Op, value Value) (after, before time.Time) {
+	t := value.Time
+
+	if value.Relative {
+		switch op {
+		case OpGt, OpGte:
+			return time.Time{}, t
+		default:
+			return t, time.Time{}
+		}
+	}
+
+	// Absolute dates cover the whole day
+	nextDay := t.AddDate(0, 0, 1)
+	switch op {
+	case OpGt:
+		return nextDay, time.Time{}
+	case OpGte:
+		return t, time.Time{}
+	case OpLt:
+		return time.Time{}, t
+	case OpLte:
+		return time.Time{}, nextDay
+	default:
+		return t, nextDay
+	}
+}
+
+// importance ranks priorities from least (none) to most (urgent) important
+func importance(priority int) int {
+	if priority == 0 {
+		return 0
+	}
+	return 5 - priority
+}
+
+// allowedPriorities returns the priority numbers a priority term matches.
+// Comparisons are by importance, so priority:>=high matches high and
+// urgent.
+func allowedPriorities(term Term) []int {
+	var allowed []int
+	for priority := 0; priority <= 4; priority++ {
+		if matchesPriority(term, priority) {
+			allowed = append(allowed, priority)
+		}
+	}
+	return allowed
+}
+
+func matchesPriority(term Term, priority int) bool {
+	if term.Op == OpEq {
+		for _, value := range term.Values {
+			if value.Priority == priority {
+				return true
+			}
+		}
+		return false
+	}
+
+	a, b := importance(priority), importance(term.Values[0].Priority)
+	switch term.Op {
+	case OpLt:
+		return a < b
+	case OpLte:
+		return a <= b
+	case OpGt:
+		return a > b
+	case OpGte:
+		return a >= b
+	}
+	return false
+}
+
+// priorityRange returns the numeric range covering exactly the given
+// priorities, if there is one
+func priorityRange(allowed []int) (int, int, bool) {
+	if len(allowed) == 0 {
+		return 0, 0, false
+	}
+
+	sort.Ints(allowed)
+	lowest, highest := allowed[0], allowed[len(allowed)-1]
+
+	// A range including none would also include urgent
+	if lowest == 0 && highest != 0 {
+		return 0, 0, false
+	}
+	if highest-lowest+1 != len(allowed) {
+		return 0, 0, false
+	}
+	return lowest, highest, true
+}
@@ -0,0 +1,206 @@
+package query
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/linear-tui/linear-tui/internal/linear"
+)
+
+// resolver resolves the teams, users and projects named in tests
+type resolver struct{}
+
+func (resolver) TeamID(name string) (string, bool) {
+	id, ok := map[string]string{"ENG": "team-eng", "DES": "team-des"}[name]
+	return id, ok
+}
+
+func (resolver) UserID(name string) (string, bool) {
+	id, ok := map[string]string{"alice": "user-alice", "bob": "user-bob"}[name]
+	return id, ok
+}
+
+func (resolver) ProjectID(name string) (string, bool) {
+	id, ok := map[string]string{"Apollo": "project-apollo"}[name]
+	return id, ok
+}
+
+func TestCompile(t *testing.T) {
+	now := time.Date(2024, 3, 15, 12, 0, 0, 0, time.Local)
+	day := func(year int, month time.Month, d int) time.Time {
+		return time.Date(year, month, d, 0, 0, 0, 0, time.Local)
+	}
+	priority := func(p int) *int { return &p }
+	base := linear.IssueFilter{TeamIDs: []string{"team"}, StateTypes: linear.OpenStateTypes}
+
+	tests := []struct {
+		input    string
+		filter   linear.IssueFilter
+		residual []Field
+		search   string
+	}{
+		{
+			input:  "",
+			filter: base,
+		},
+		{
+			input:  "assignee:me,none,alice",
+			filter: linear.IssueFilter{TeamIDs: []string{"team"}, StateTypes: linear.OpenStateTypes, AssignedToMe: true, Unassigned: true, AssigneeIDs: []string{"user-alice"}},
+		},
+		{
+			// Filtering on status drops the open state types of the base
+			input:  `status:"In Review"`,
+			filter: linear.IssueFilter{TeamIDs: []string{"team"}, StateNames: []string{"In Review"}},
+		},
+		{
+			input:  "state:closed",
+			filter: linear.IssueFilter{TeamIDs: []string{"team"}, StateTypes: []string{"completed", "canceled"}},
+		},
+		{
+			// Only the first term of a field goes to Linear
+			input:    "status:Todo status:Done",
+			filter:   linear.IssueFilter{TeamIDs: []string{"team"}, StateNames: []string{"Todo"}},
+			residual: []Field{FieldStatus},
+		},
+		{
+			input:    "assignee:alice assignee:bob",
+			filter:   linear.IssueFilter{TeamIDs: []string{"team"}, StateTypes: linear.OpenStateTypes, AssigneeIDs: []string{"user-alice"}},
+			residual: []Field{FieldAssignee},
+		},
+		{
+			// Negated terms are matched against loaded issues
+			input:    "-assignee:alice -priority:urgent",
+			filter:   base,
+			residual: []Field{FieldAssignee, FieldPriority},
+		},
+		{
+			input:  "priority:>=high",
+			filter: linear.IssueFilter{TeamIDs: []string{"team"}, StateTypes: linear.OpenStateTypes, MinPriority: priority(1), MaxPriority: priority(2)},
+		},
+		{
+			// Low and none are not a range Linear can express
+			input:    "priority:<=low",
+			filter:   base,
+			residual: []Field{FieldPriority},
+		},
+		{
+			input:    "project:Apollo cycle:12 cycle:none",
+			filter:   linear.IssueFilter{TeamIDs: []string{"team"}, StateTypes: linear.OpenStateTypes, ProjectID: "project-apollo", CycleNumber: 12},
+			residual: []Field{FieldCycle},
+		},
+		{
+			// Filtering on team replaces the teams of the base
+			input:  "team:ENG,DES cycle:12 state:triage,open,closed",
+			filter: linear.IssueFilter{TeamIDs: []string{"team-eng", "team-des"}, CycleNumber: 12, StateTypes: []string{"triage", "backlog", "unstarted", "started", "completed", "canceled"}},
+		},
+		{
+			input:    "-team:ENG",
+			filter:   base,
+			residual: []Field{FieldTeam},
+		},
+		{
+			input:  "cycle:active",
+			filter: linear.IssueFilter{TeamIDs: []string{"team"}, StateTypes: linear.OpenStateTypes, Cycle: linear.CycleActive},
+		},
+		{
+			input:  "created:2024-01-31",
+			filter: linear.IssueFilter{TeamIDs: []string{"team"}, StateTypes: linear.OpenStateTypes, CreatedAfter: day(2024, 1, 31), CreatedBefore: day(2024, 2, 1)},
+		},
+		{
+			input:  "created:>2024-01-31",
+			filter: linear.IssueFilter{TeamIDs: []string{"team"}, StateTypes: linear.OpenStateTypes, CreatedAfter: day(2024, 2, 1)},
+		},
+		{
+			input:  "created:>=2024-01-31",
+			filter: linear.IssueFilter{TeamIDs: []string{"team"}, StateTypes: linear.OpenStateTypes, CreatedAfter: day(2024, 1, 31)},
+		},
+		{
+			input:  "created:<2024-01-31",
+			filter: linear.IssueFilter{TeamIDs: []string{"team"}, StateTypes: linear.OpenStateTypes, CreatedBefore: day(2024, 1, 31)},
+		},
+		{
+			input:  "created:<=2024-01-31",
+			filter: linear.IssueFilter{TeamIDs: []string{"team"}, StateTypes: linear.OpenStateTypes, CreatedBefore: day(2024, 2, 1)},
+		},
+		{
+			// Ages compare the other way round: less than 7 days ago
+			input:  "updated:<7d",
+			filter: linear.IssueFilter{TeamIDs: []string{"team"}, StateTypes: linear.OpenStateTypes, UpdatedAfter: now.Add(-7 * 24 * time.Hour)},
+		},
+		{
+			input:  "updated:>2w",
+			filter: linear.IssueFilter{TeamIDs: []string{"team"}, StateTypes: linear.OpenStateTypes, UpdatedBefore: now.Add(-14 * 24 * time.Hour)},
+		},
+		{
+			input:    "id:ENG-1,ENG-2",
+			filter:   base,
+			residual: []Field{FieldID},
+		},
+		{
+			input:  "crash -flaky login",
+			filter: base,
+			search: "crash login",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			q, err := Parse(tt.input)
+			if err != nil {
+				t.Fatalf("Parse(%q) failed: %v", tt.input, err)
+			}
+			compiled, err := Compile(q, base, resolver{}, now)
+			if err != nil {
+				t.Fatalf("Compile(%q) failed: %v", tt.input, err)
+			}
+			if !reflect.DeepEqual(compiled.Filter, tt.filter) {
+				t.Errorf("Compile(%q) filter = %+v, want %+v", tt.input, compiled.Filter, tt.filter)
+			}
+			var residual []Field
+			for _, term := range compiled.Residual.Terms {
+				residual = append(residual, term.Field)
+			}
+			if !reflect.DeepEqual(residual, tt.residual) {
+				t.Errorf("Compile(%q) residual = %v, want %v", tt.input, residual, tt.residual)
+			}
+			if compiled.Search != tt.search {
+				t.Errorf("Compile(%q) search = %q, want %q", tt.input, compiled.Search, tt.search)
+			}
+		})
+	}
+}
+
+func TestCompileErrors(t *testing.T) {
+	tests := []struct {
+		input   string
+		span    Span
+		message string
+	}{
+		{"team:OPS", Span{5, 8}, `unknown team "OPS"`},
+		{"assignee:carol", Span{9, 14}, `unknown user "carol"`},
+		{"project:Gemini", Span{8, 14}, `unknown project "Gemini"`},
+		{"label:bug label:ui", Span{10, 18}, "label can only be used once and cannot be negated"},
+		{"-label:bug", Span{0, 10}, "label can only be used once and cannot be negated"},
+		{"-assignee:me", Span{10, 12}, "assignee:me can only be used once and cannot be negated"},
+		{"cycle:active,next", Span{6, 12}, "cycle:active can only be used once and cannot be negated"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			q, err := Parse(tt.input)
+			if err != nil {
+				t.Fatalf("Parse(%q) failed: %v", tt.input, err)
+			}
+			_, err = Compile(q, linear.IssueFilter{}, resolver{}, time.Now())
+			var queryErr *Error
+			if !errors.As(err, &queryErr) {
+				t.Fatalf("Compile(%q) error = %v, want a query error", tt.input, err)
+			}
+			if queryErr.Span != tt.span || queryErr.Message != tt.message {
+				t.Errorf("Compile(%q) error = %+v, want %q at %+v", tt.input, queryErr, tt.message, tt.span)
+			}
+		})
+	}
+}
@@ -0,0 +1,110 @@
+package query
+
+import (
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/linear-tui/linear-tui/internal/domain"
+)
+
+// Match reports whether an issue passes the client-side part of a compiled
+// query
+func (c Compiled) Match(issue domain.Issue) bool {
+	return c.Residual.Match(issue)
+}
+
+// Match reports whether an issue matches every term of the query
+func (q Query) Match(issue domain.Issue) bool {
+	for _, term := range q.Terms {
+		if matchTerm(term, issue) == term.Negated {
+			return false
+		}
+	}
+
+	for _, text := range q.Text {
+		if containsFold(issue.Title+"\n"+issue.Description, text.Text) == text.Negated {
+			return false
+		}
+	}
+
+	return true
+}
+
+// matchTerm reports whether an issue matches any value of a term, ignoring
+// negation
+func matchTerm(term Term, issue domain.Issue) bool {
+	switch term.Field {
+	case FieldPriority:
+		priority, ok := priorities[strings.ToLower(issue.Priority)]
+		return ok && matchesPriority(term, priority)
+
+	case FieldCreated, FieldUpdated:
+		t := issue.CreatedAt
+		if term.Field == FieldUpdated {
+			t = issue.UpdatedAt
+		}
+		for _, value := range term.Values {
+			after, before := dateBounds(term.Op, value)
+			if (after.IsZero() || !t.Before(after)) && (before.IsZero() || t.Before(before)) {
+				return true
+			}
+		}
+		return false
+	}
+
+	for _, value := range term.Values {
+		if matchValue(term.Field, value.Raw, issue) {
+			return true
+		}
+	}
+	return false
+}
+
+func matchValue(field Field, raw string, issue domain.Issue) bool {
+	switch field {
+	case FieldTeam:
+		return strings.EqualFold(issue.Team, raw) || hasPrefixFold(issue.ID, raw+"-")
+
+	case FieldAssignee:
+		if strings.EqualFold(raw, "none") {
+			return issue.Assignee == "" || issue.Assignee == "Unassigned"
+		}
+		return containsFold(issue.Assignee, raw)
+
+	case FieldStatus:
+		return strings.EqualFold(issue.Status, raw)
+
+	case FieldState:
+		for _, stateType := range stateTypes[strings.ToLower(raw)] {
+			if issue.StateType == stateType {
+				return true
+			}
+		}
+		return false
+
+	case FieldProject:
+		return containsFold(issue.Project, raw)
+
+	case FieldCycle:
+		if strings.EqualFold(raw, "none") {
+			return issue.CycleID == ""
+		}
+		n, err := strconv.Atoi(raw)
+		return err == nil && issue.CycleNumber == n
+
+	case FieldID:
+		matched, err := path.Match(strings.ToUpper(raw), strings.ToUpper(issue.ID))
+		return err == nil && matched
+	}
+
+	return false
+}
+
+func hasPrefixFold(s, prefix string) bool {
+	return len(s) >= len(prefix) && strings.EqualFold(s[:len(prefix)], prefix)
+}
+
+func containsFold(s, substr string) bool {
+	return strings.Contains(strings.ToLower(s), strings.ToLower(substr))
+}
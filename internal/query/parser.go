@@ -0,0 +1,292 @@
+package query
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/linear-tui/linear-tui/internal/linear"
+)
+
+// priorities maps priority names to Linear priority numbers
+var priorities = map[string]int{
+	"none":   0,
+	"urgent": 1,
+	"high":   2,
+	"normal": 3,
+	"medium": 3,
+	"low":    4,
+}
+
+// stateTypes maps the values accepted by the state field to Linear
+// workflow state types
+var stateTypes = map[string][]string{
+	"triage":    {"triage"},
+	"backlog":   {"backlog"},
+	"unstarted": {"unstarted"},
+	"todo":      {"unstarted"},
+	"started":   {"started"},
+	"completed": {"completed"},
+	"done":      {"completed"},
+	"canceled":  {"canceled"},
+	"cancelled": {"canceled"},
+	"open":      {"backlog", "unstarted", "started"},
+	"closed":    {"completed", "canceled"},
+}
+
+// cycles maps the relative values accepted by the cycle field to Linear's
+// relative cycles. Cycle numbers are accepted too.
+var cycles = map[string]string{
+	"active":   linear.CycleActive,
+	"current":  linear.CycleActive,
+	"next":     linear.CycleNext,
+	"upcoming": linear.CycleNext,
+	"previous": linear.CyclePrevious,
+	"last":     linear.CyclePrevious,
+	"none":     linear.CycleNone,
+}
+
+// Parse parses a filter query
+func Parse(input string) (Query, error) {
+	p := parser{input: input}
+	return p.parse()
+}
+
+type parser struct {
+	input string
+	pos   int
+}
+
+func (p *parser) parse() (Query, error) {
+	var q Query
+
+	for {
+		p.skipSpace()
+		if p.eof() {
+			return q, nil
+		}
+
+		start := p.pos
+		negated := false
+		if p.peek() == '-' && p.pos+1 < len(p.input) && !isSpace(p.input[p.pos+1]) {
+			negated = true
+			p.pos++
+		}
+
+		field, fieldSpan, isField, err := p.field()
+		if err != nil {
+			return Query{}, err
+		}
+
+		if !isField {
+			raw, _, err := p.value(false)
+			if err != nil {
+				return Query{}, err
+			}
+			q.Text = append(q.Text, TextTerm{
+				Text:    raw,
+				Negated: negated,
+				Span:    Span{start, p.pos},
+			})
+			continue
+		}
+
+		term, err := p.term(field, fieldSpan)
+		if err != nil {
+			return Query{}, err
+		}
+		term.Negated = negated
+		term.Span = Span{start, p.pos}
+		q.Terms = append(q.Terms, term)
+	}
+}
+
+// field consumes a `name:` prefix if there is one
+func (p *parser) field() (Field, Span, bool, error) {
+	end := p.pos
+	for end < len(p.input) && isLetter(p.input[end]) {
+		end++
+	}
+	if end == p.pos || end >= len(p.input) || p.input[end] != ':' {
+		return "", Span{}, false, nil
+	}
+
+	span := Span{p.pos, end}
+	name := strings.ToLower(p.input[p.pos:end])
+	field, ok := fields[name]
+	if !ok {
+		return "", Span{}, false, errorAt(span, "unknown field %q", name)
+	}
+
+	p.pos = end + 1
+	return field, span, true, nil
+}
+
+// term parses the operator and values following `field:`
+func (p *parser) term(field Field, fieldSpan Span) (Term, error) {
+	term := Term{Field: field, Op: OpEq, FieldSpan: fieldSpan}
+
+	opStart := p.pos
+	for _, op := range []Op{OpGte, OpLte, OpGt, OpLt, OpEq} {
+		if strings.HasPrefix(p.input[p.pos:], string(op)) {
+			term.Op = op
+			p.pos += len(op)
+			break
+		}
+	}
+	opSpan := Span{opStart, p.pos}
+
+	if term.Op != OpEq && !isComparable(field) {
+		return Term{}, errorAt(opSpan, "%s does not support %s", field, term.Op)
+	}
+
+	for {
+		raw, span, err := p.value(true)
+		if err != nil {
+			return Term{}, err
+		}
+		if raw == "" {
+			return Term{}, errorAt(Span{span.Start, span.Start + 1}, "missing value for %s", field)
+		}
+
+		value, err := parseValue(field, raw, span)
+		if err != nil {
+			return Term{}, err
+		}
+		term.Values = append(term.Values, value)
+
+		if p.eof() || p.peek() != ',' {
+			break
+		}
+		p.pos++
+	}
+
+	if term.Op != OpEq && len(term.Values) > 1 {
+		return Term{}, errorAt(opSpan, "%s cannot be combined with several values", term.Op)
+	}
+
+	return term, nil
+}
+
+// value consumes a bare word or a quoted string. Words in field values end
+// at a comma, which separates values.
+func (p *parser) value(inField bool) (string, Span, error) {
+	start := p.pos
+
+	if !p.eof() && p.peek() == '"' {
+		end := strings.IndexByte(p.input[p.pos+1:], '"')
+		if end < 0 {
+			return "", Span{}, errorAt(Span{start, len(p.input)}, "unterminated quote")
+		}
+		p.pos += end + 2
+		return p.input[start+1 : p.pos-1], Span{start, p.pos}, nil
+	}
+
+	for !p.eof() && !isSpace(p.peek()) && p.peek() != '"' {
+		if inField && p.peek() == ',' {
+			break
+		}
+		p.pos++
+	}
+	return p.input[start:p.pos], Span{start, p.pos}, nil
+}
+
+func (p *parser) skipSpace() {
+	for !p.eof() && isSpace(p.peek()) {
+		p.pos++
+	}
+}
+
+func (p *parser) peek() byte {
+	return p.input[p.pos]
+}
+
+func (p *parser) eof() bool {
+	return p.pos >= len(p.input)
+}
+
+// parseValue checks a raw value against its field and fills in its parsed
+// form
+func parseValue(field Field, raw string, span Span) (Value, error) {
+	value := Value{Raw: raw, Span: span}
+
+	switch field {
+	case FieldPriority:
+		lower := strings.ToLower(raw)
+		if n, err := strconv.Atoi(lower); err == nil && n >= 0 && n <= 4 {
+			value.Priority = n
+		} else if n, ok := priorities[lower]; ok {
+			value.Priority = n
+		} else {
+			return Value{}, errorAt(span, "unknown priority %q (use none, urgent, high, normal or low)", raw)
+		}
+
+	case FieldState:
+		if _, ok := stateTypes[strings.ToLower(raw)]; !ok {
+			return Value{}, errorAt(span, "unknown state %q (use backlog, todo, started, done, canceled, open or closed)", raw)
+		}
+
+	case FieldCycle:
+		if n, err := strconv.Atoi(raw); err == nil && n > 0 {
+			value.Cycle = n
+		} else if _, ok := cycles[strings.ToLower(raw)]; !ok {
+			return Value{}, errorAt(span, "unknown cycle %q (use active, next, previous, none or a cycle number)", raw)
+		}
+
+	case FieldCreated, FieldUpdated:
+		if age, ok := parseAge(raw); ok {
+			value.Age = age
+			value.Relative = true
+		} else if t, err := time.ParseInLocation("2006-01-02", raw, time.Local); err == nil {
+			value.Time = t
+		} else {
+			return Value{}, errorAt(span, "invalid date %q (use a date like 2024-01-31 or an age like 7d)", raw)
+		}
+	}
+
+	return value, nil
+}
+
+// parseAge parses ages such as 12h, 7d, 2w, 3m or 1y
+func parseAge(raw string) (time.Duration, bool) {
+	if len(raw) < 2 {
+		return 0, false
+	}
+
+	n, err := strconv.Atoi(raw[:len(raw)-1])
+	if err != nil || n < 0 {
+		return 0, false
+	}
+
+	day := 24 * time.Hour
+	switch raw[len(raw)-1] {
+	case 'h':
+		return time.Duration(n) * time.Hour, true
+	case 'd':
+		return time.Duration(n) * day, true
+	case 'w':
+		return time.Duration(n) * 7 * day, true
+	case 'm':
+		return time.Duration(n) * 30 * day, true
+	case 'y':
+		return time.Duration(n) * 365 * day, true
+	}
+	return 0, false
+}
+
+// isComparable reports whether a field supports <, <=, > and >=
+func isComparable(field Field) bool {
+	switch field {
+	case FieldPriority, FieldCreated, FieldUpdated:
+		return true
+	}
+	return false
+}
+
+func isSpace(c byte) bool {
+	return c == ' ' || c == '\t'
+}
+
+func isLetter(c byte) bool {
+	return (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
@@ -0,0 +1,142 @@
+package query
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		input string
+		terms []Term
+		text  []TextTerm
+	}{
+		{
+			input: "assignee:me",
+			terms: []Term{{Field: FieldAssignee, Op: OpEq, Values: []Value{{Raw: "me"}}, Span: Span{0, 11}, FieldSpan: Span{0, 8}}},
+		},
+		{
+			input: `status:"In Progress",Todo`,
+			terms: []Term{{Field: FieldStatus, Op: OpEq, Values: []Value{{Raw: "In Progress"}, {Raw: "Todo"}}, Span: Span{0, 25}, FieldSpan: Span{0, 6}}},
+		},
+		{
+			input: "-label:bug",
+			terms: []Term{{Field: FieldLabel, Op: OpEq, Values: []Value{{Raw: "bug"}}, Negated: true, Span: Span{0, 10}, FieldSpan: Span{1, 6}}},
+		},
+		{
+			input: "prio:>=high",
+			terms: []Term{{Field: FieldPriority, Op: OpGte, Values: []Value{{Raw: "high", Priority: 2}}, Span: Span{0, 11}, FieldSpan: Span{0, 4}}},
+		},
+		{
+			input: "crash -flaky - bar",
+			text: []TextTerm{
+				{Text: "crash", Span: Span{0, 5}},
+				{Text: "flaky", Negated: true, Span: Span{6, 12}},
+				{Text: "-", Span: Span{13, 14}},
+				{Text: "bar", Span: Span{15, 18}},
+			},
+		},
+		{
+			input: `"status:done" -"won't fix"`,
+			text: []TextTerm{
+				{Text: "status:done", Span: Span{0, 13}},
+				{Text: "won't fix", Negated: true, Span: Span{14, 26}},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			q, err := Parse(tt.input)
+			if err != nil {
+				t.Fatalf("Parse(%q) failed: %v", tt.input, err)
+			}
+			if len(q.Terms) != len(tt.terms) {
+				t.Fatalf("Parse(%q) gave %d terms, want %d", tt.input, len(q.Terms), len(tt.terms))
+			}
+			for i, want := range tt.terms {
+				got := q.Terms[i]
+				if got.Field != want.Field || got.Op != want.Op || got.Negated != want.Negated || got.Span != want.Span || got.FieldSpan != want.FieldSpan {
+					t.Errorf("term %d = %+v, want %+v", i, got, want)
+				}
+				if len(got.Values) != len(want.Values) {
+					t.Fatalf("term %d has %d values, want %d", i, len(got.Values), len(want.Values))
+				}
+				for j, value := range want.Values {
+					if got.Values[j].Raw != value.Raw || got.Values[j].Priority != value.Priority {
+						t.Errorf("term %d value %d = %+v, want %+v", i, j, got.Values[j], value)
+					}
+				}
+			}
+			if len(q.Text) != len(tt.text) {
+				t.Fatalf("Parse(%q) gave text %+v, want %+v", tt.input, q.Text, tt.text)
+			}
+			for i, want := range tt.text {
+				if q.Text[i] != want {
+					t.Errorf("text %d = %+v, want %+v", i, q.Text[i], want)
+				}
+			}
+		})
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := []struct {
+		input   string
+		span    Span
+		message string
+	}{
+		{"owner:me", Span{0, 5}, `unknown field "owner"`},
+		{"bug status:", Span{11, 12}, "missing value for status"},
+		{"label:a,", Span{8, 9}, "missing value for label"},
+		{`title "open`, Span{6, 11}, "unterminated quote"},
+		{"label:>bug", Span{6, 7}, "label does not support >"},
+		{"priority:<high,low", Span{9, 10}, "< cannot be combined with several values"},
+		{"priority:highest", Span{9, 16}, `unknown priority "highest" (use none, urgent, high, normal or low)`},
+		{"state:review", Span{6, 12}, `unknown state "review" (use backlog, todo, started, done, canceled, open or closed)`},
+		{"cycle:0", Span{6, 7}, `unknown cycle "0" (use active, next, previous, none or a cycle number)`},
+		{"created:yesterday", Span{8, 17}, `invalid date "yesterday" (use a date like 2024-01-31 or an age like 7d)`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			_, err := Parse(tt.input)
+			var queryErr *Error
+			if !errors.As(err, &queryErr) {
+				t.Fatalf("Parse(%q) error = %v, want a query error", tt.input, err)
+			}
+			if queryErr.Span != tt.span || queryErr.Message != tt.message {
+				t.Errorf("Parse(%q) error = %+v, want %q at %+v", tt.input, queryErr, tt.message, tt.span)
+			}
+		})
+	}
+}
+
+func TestParseDates(t *testing.T) {
+	tests := []struct {
+		input    string
+		age      time.Duration
+		date     time.Time
+		relative bool
+	}{
+		{input: "updated:<12h", age: 12 * time.Hour, relative: true},
+		{input: "updated:<7d", age: 7 * 24 * time.Hour, relative: true},
+		{input: "created:>2w", age: 14 * 24 * time.Hour, relative: true},
+		{input: "created:1y", age: 365 * 24 * time.Hour, relative: true},
+		{input: "created:2024-01-31", date: time.Date(2024, 1, 31, 0, 0, 0, 0, time.Local)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			q, err := Parse(tt.input)
+			if err != nil {
+				t.Fatalf("Parse(%q) failed: %v", tt.input, err)
+			}
+			value := q.Terms[0].Values[0]
+			if value.Relative != tt.relative || value.Age != tt.age || !value.Time.Equal(tt.date) {
+				t.Errorf("Parse(%q) value = %+v, want age %v, date %v, relative %v", tt.input, value, tt.age, tt.date, tt.relative)
+			}
+		})
+	}
+}
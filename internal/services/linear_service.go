@@ -3,14 +3,20 @@ package services
 import (
 	"context"
 	"fmt"
+	"sort"
+	"sync"
 	"time"
 
 	"github.com/linear-tui/linear-tui/internal/adapters"
+	"github.com/linear-tui/linear-tui/internal/cache"
 	"github.com/linear-tui/linear-tui/internal/config"
 	"github.com/linear-tui/linear-tui/internal/domain"
 	"github.com/linear-tui/linear-tui/internal/linear"
 )
 
+// pageSize is the number of items fetched per page for list views
+const pageSize = 50
+
 // LinearService handles all Linear API interactions and data conversion
 type LinearService struct {
 	client        *linear.Client
@@ -18,7 +24,32 @@ type LinearService struct {
 	defaultTeam   *linear.Team
 	teams         []linear.Team
 	users         []linear.User
+	viewer        *linear.User
 	lastDataFetch time.Time
+
+	// allTeams widens the issue list and board from the default team to
+	// every team. New issues still go to the default team.
+	allTeams bool
+
+	// mu guards the per-team caches, which are filled from background
+	// commands, and the workspace data and team mode above, which Sync
+	// and the team picker replace
+	mu          sync.Mutex
+	issueStates map[string][]linear.IssueState
+	labels      map[string][]linear.IssueLabel
+
+	// workspaceFetched is set once the teams and users have been fetched
+	// in this run. They rarely change, so Sync fetches them only until
+	// then, or again after RefreshData.
+	workspaceFetched bool
+
+	// cache is the copy of the workspace kept on disk between runs, and
+	// outbox the edits made while Linear could not be reached
+	cache  *cache.Cache
+	outbox *cache.Outbox
+
+	// ledger holds the updates shown before Linear has confirmed them
+	ledger ledger
 }
 
 // NewLinearService creates a new LinearService
@@ -32,62 +63,122 @@ func NewLinearService(cfg *config.Config) (*LinearService, error) {
 		return nil, fmt.Errorf("failed to create Linear client: %w", err)
 	}
 
+	// Without a cache directory the cache is only kept in memory
+	cachePath, err := cache.Path(cfg.LinearAPIKey)
+	if err != nil {
+		cachePath = ""
+	}
+	outboxPath, err := cache.OutboxPath(cfg.LinearAPIKey)
+	if err != nil {
+		outboxPath = ""
+	}
+
 	service := &LinearService{
-		client:  client,
-		adapter: adapters.NewLinearAdapter(),
+		client:      client,
+		adapter:     adapters.NewLinearAdapter(),
+		allTeams:    cfg.AllTeams,
+		issueStates: make(map[string][]linear.IssueState),
+		labels:      make(map[string][]linear.IssueLabel),
+		cache:       cache.Open(cachePath),
+		outbox:      cache.OpenOutbox(outboxPath),
+	}
+
+	// A workspace cached by an earlier run is shown straight away, and
+	// Sync checks the API key and brings it up to date
+	if viewer, teams, users, ok := service.cache.Workspace(); ok {
+		service.setWorkspace(viewer, teams, users, cfg.DefaultTeamID)
+		return service, nil
 	}
 
 	// Initialize basic data
-	if err := service.initialize(); err != nil {
+	if err := service.initialize(cfg.DefaultTeamID); err != nil {
 		return nil, fmt.Errorf("failed to initialize service: %w", err)
 	}
 
 	return service, nil
 }
 
-// initialize fetches basic workspace data needed for operations
-func (s *LinearService) initialize() error {
+// initialize fetches basic workspace data needed for operations. The team
+// with ID teamID becomes the default team if it is still available.
+func (s *LinearService) initialize(teamID string) error {
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 	defer cancel()
 
-	// Validate API key first
-	if err := s.client.ValidateAPIKey(ctx); err != nil {
-		return fmt.Errorf("API key validation failed: %w", err)
+	if err := s.refreshWorkspace(ctx, teamID); err != nil {
+		return err
+	}
+	s.saveCache()
+	return nil
+}
+
+// refreshWorkspace fetches the workspace data and replaces it, keeping the
+// team with ID teamID as the default team if it is still available
+func (s *LinearService) refreshWorkspace(ctx context.Context, teamID string) error {
+	viewer, teams, users, err := s.fetchWorkspace(ctx)
+	if err != nil {
+		return err
+	}
+	s.setWorkspace(viewer, teams, users, teamID)
+	s.mu.Lock()
+	s.workspaceFetched = true
+	s.mu.Unlock()
+	return nil
+}
+
+// fetchWorkspace fetches the authenticated user, the teams and the users
+func (s *LinearService) fetchWorkspace(ctx context.Context) (*linear.User, []linear.Team, []linear.User, error) {
+	// Validate API key first, which also identifies the user it belongs to
+	viewer, err := s.client.ValidateAPIKey(ctx)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("API key validation failed: %w", err)
 	}
 
 	// Fetch teams
 	teams, err := s.client.GetTeams(ctx)
 	if err != nil {
-		return fmt.Errorf("failed to fetch teams: %w", err)
+		return nil, nil, nil, fmt.Errorf("failed to fetch teams: %w", err)
 	}
-	s.teams = teams
 
-	// Set default team (first available team)
-	if len(s.teams) > 0 {
-		s.defaultTeam = &s.teams[0]
+	if len(teams) == 0 {
+		return nil, nil, nil, fmt.Errorf("no teams found - please check your Linear workspace access")
 	}
 
 	// Fetch users for assignee lookups
 	users, err := s.client.GetUsers(ctx)
 	if err != nil {
-		return fmt.Errorf("failed to fetch users: %w", err)
+		return nil, nil, nil, fmt.Errorf("failed to fetch users: %w", err)
 	}
-	s.users = users
 
-	s.lastDataFetch = time.Now()
-	return nil
+	return viewer, teams, users, nil
+}
+
+// setWorkspace replaces the workspace data and caches it. The team with ID
+// teamID becomes the default team if it is still available, falling back
+// to the first team.
+func (s *LinearService) setWorkspace(viewer *linear.User, teams []linear.Team, users []linear.User, teamID string) {
+	s.mu.Lock()
+	s.viewer, s.teams, s.users = viewer, teams, users
+	s.defaultTeam = &s.teams[0]
+	for i := range s.teams {
+		if s.teams[i].ID == teamID {
+			s.defaultTeam = &s.teams[i]
+		}
+	}
+	s.mu.Unlock()
+
+	s.cache.SetWorkspace(viewer, teams, users)
 }
 
-// GetTickets fetches issues from Linear and converts them to domain Issues for UI usage
+// GetTickets fetches all issues for the default team and converts them to domain Issues for UI usage
 func (s *LinearService) GetTickets() ([]domain.Issue, error) {
-	if s.defaultTeam == nil {
+	if s.GetDefaultTeam() == nil {
 		return nil, fmt.Errorf("no default team available - please check your Linear workspace access")
 	}
 
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 	defer cancel()
 
-	issues, err := s.client.GetIssues(ctx, s.defaultTeam.ID, 50) // Fetch up to 50 issues
+	issues, err := s.client.GetIssues(ctx, s.DefaultIssueFilter(), linear.DefaultFetchAllLimit)
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch issues from Linear API: %w", err)
 	}
@@ -97,31 +188,228 @@ func (s *LinearService) GetTickets() ([]domain.Issue, error) {
 	return uiIssues, nil
 }
 
-// GetTicketByID fetches a single issue by ID from Linear and converts it to domain Issue
+// GetTicketsPage fetches one page of open issues for the default team,
+// starting after cursor. The returned cursor is empty when there are no
+// more pages.
+func (s *LinearService) GetTicketsPage(cursor string) ([]domain.Issue, string, error) {
+	if s.GetDefaultTeam() == nil {
+		return nil, "", fmt.Errorf("no default team available - please check your Linear workspace access")
+	}
+
+	return s.GetFilteredTicketsPage(s.DefaultIssueFilter(), cursor)
+}
+
+// GetFilteredTicketsPage fetches one page of issues matching filter,
+// starting after cursor. The returned cursor is empty when there are no
+// more pages.
+func (s *LinearService) GetFilteredTicketsPage(filter linear.IssueFilter, cursor string) ([]domain.Issue, string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	page, err := s.client.GetIssuesPage(ctx, filter, linear.PageOptions{First: pageSize, After: cursor})
+	if err != nil {
+		if issues, ok := s.offlineTickets(filter, err); ok {
+			return issues, "", nil
+		}
+		return nil, "", fmt.Errorf("failed to fetch issues from Linear API: %w", err)
+	}
+	s.cache.PutIssues(page.Nodes...)
+
+	return s.adapter.ConvertIssuesToUIModels(page.Nodes), nextCursor(page.PageInfo), nil
+}
+
+// SearchTicketsPage fetches one page of the issues matching a full-text
+// search term and filter, starting after cursor. The returned cursor is
+// empty when there are no more pages.
+func (s *LinearService) SearchTicketsPage(term string, filter linear.IssueFilter, cursor string) ([]domain.Issue, string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	page, err := s.client.SearchIssuesPage(ctx, term, filter, linear.PageOptions{First: pageSize, After: cursor})
+	if err != nil {
+		filter.Search = term
+		if issues, ok := s.offlineTickets(filter, err); ok {
+			return issues, "", nil
+		}
+		return nil, "", fmt.Errorf("failed to search issues: %w", err)
+	}
+	s.cache.PutIssues(page.Nodes...)
+
+	return s.adapter.ConvertIssuesToUIModels(page.Nodes), nextCursor(page.PageInfo), nil
+}
+
+// DefaultIssueFilter returns the filter for the main issue list: open
+// issues of the default team, or of every team in all-teams mode
+func (s *LinearService) DefaultIssueFilter() linear.IssueFilter {
+	filter := linear.IssueFilter{
+		StateTypes: linear.OpenStateTypes,
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.defaultTeam != nil && !s.allTeams {
+		filter.TeamIDs = []string{s.defaultTeam.ID}
+	}
+	return filter
+}
+
+// MyIssuesFilter returns the filter for the issues assigned to the
+// authenticated user that are still open, in any team
+func (s *LinearService) MyIssuesFilter() linear.IssueFilter {
+	return linear.IssueFilter{
+		AssignedToMe: true,
+		StateTypes:   linear.OpenStateTypes,
+	}
+}
+
+// CreatedIssuesFilter returns the filter for the issues created by the
+// authenticated user that are still open, in any team
+func (s *LinearService) CreatedIssuesFilter() linear.IssueFilter {
+	filter := linear.IssueFilter{
+		StateTypes: linear.OpenStateTypes,
+	}
+	if viewer := s.GetViewer(); viewer != nil {
+		filter.CreatorIDs = []string{viewer.ID}
+	}
+	return filter
+}
+
+// GetTicketByID fetches a single issue by ID from Linear and converts it to
+// domain Issue. Issues created offline and issues fetched while Linear
+// cannot be reached come from the cache.
 func (s *LinearService) GetTicketByID(issueID string) (*domain.Issue, error) {
+	if isLocalID(issueID) {
+		if issue, ok := s.CachedTicket(issueID); ok {
+			return issue, nil
+		}
+	}
+
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 	defer cancel()
 
 	issue, err := s.client.GetIssueByID(ctx, issueID)
 	if err != nil {
+		if cached, ok := s.CachedTicket(issueID); ok && isNetworkError(err) {
+			return cached, nil
+		}
 		return nil, fmt.Errorf("failed to fetch issue from Linear API: %w", err)
 	}
+	s.cache.PutIssues(*issue)
 
 	// Convert to domain issue for UI usage
 	uiIssue := s.adapter.ConvertIssueToUIModel(*issue)
 	return &uiIssue, nil
 }
 
+// GetTicketComments fetches the comments on an issue, oldest first,
+// followed by those waiting in the outbox. The queued comments are
+// returned along with any error fetching the others.
+func (s *LinearService) GetTicketComments(issueID string) ([]domain.Comment, error) {
+	queued := s.queuedComments(issueID)
+	if isLocalID(issueID) {
+		return queued, nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	comments, err := s.client.GetIssueComments(ctx, issueID, linear.DefaultFetchAllLimit)
+	if err != nil {
+		return queued, fmt.Errorf("failed to fetch comments from Linear API: %w", err)
+	}
+
+	return append(s.adapter.ConvertCommentsToUIModels(comments), queued...), nil
+}
+
+// GetTicketHistory fetches the changes to the status, assignee, priority
+// and labels of an issue
+func (s *LinearService) GetTicketHistory(issueID string) ([]domain.HistoryEvent, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	history, err := s.client.GetIssueHistory(ctx, issueID, linear.DefaultFetchAllLimit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch issue history from Linear API: %w", err)
+	}
+
+	return s.adapter.ConvertIssueHistoryToUIModels(history), nil
+}
+
+// CreateComment posts a comment on an issue. While Linear cannot be
+// reached, the comment is queued in the outbox instead.
+func (s *LinearService) CreateComment(issueID, body string) (*domain.Comment, error) {
+	if s.queueing() || isLocalID(issueID) {
+		return s.queueComment(issueID, body)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	comment, err := s.client.CreateComment(ctx, "", issueID, body)
+	if err != nil {
+		if isNetworkError(err) {
+			return s.queueComment(issueID, body)
+		}
+		return nil, fmt.Errorf("failed to create comment: %w", err)
+	}
+
+	uiComment := s.adapter.ConvertCommentToUIModel(*comment)
+	return &uiComment, nil
+}
+
+// DeleteComment deletes a comment
+func (s *LinearService) DeleteComment(commentID string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	if err := s.client.DeleteComment(ctx, commentID); err != nil {
+		return fmt.Errorf("failed to delete comment: %w", err)
+	}
+	return nil
+}
+
+// GetTicketRelations fetches the parent, sub-issues and related issues of
+// an issue
+func (s *LinearService) GetTicketRelations(issueID string) (*domain.IssueRelations, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	relations, err := s.client.GetIssueRelations(ctx, issueID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch issue relations: %w", err)
+	}
+
+	uiRelations := s.adapter.ConvertIssueRelationsToUIModel(*relations)
+	return &uiRelations, nil
+}
+
+// LinkTickets links an issue to another one with one of the domain
+// relation types, such as domain.RelationBlocks
+func (s *LinearService) LinkTickets(issueID, relatedID, relation string) error {
+	input, err := s.adapter.ConvertRelationToInput(issueID, relatedID, relation)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	if err := s.client.CreateIssueRelation(ctx, input); err != nil {
+		return fmt.Errorf("failed to link issues: %w", err)
+	}
+	return nil
+}
+
 // GetProjects fetches projects from Linear and converts them to domain Projects for UI usage
 func (s *LinearService) GetProjects() ([]domain.Project, error) {
-	if s.defaultTeam == nil {
+	team := s.GetDefaultTeam()
+	if team == nil {
 		return nil, fmt.Errorf("no default team available - please check your Linear workspace access")
 	}
 
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 	defer cancel()
 
-	projects, err := s.client.GetProjects(ctx, s.defaultTeam.ID)
+	projects, err := s.client.GetProjects(ctx, team.ID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch projects from Linear API: %w", err)
 	}
@@ -131,150 +419,360 @@ func (s *LinearService) GetProjects() ([]domain.Project, error) {
 	return uiProjects, nil
 }
 
-// CreateTicket creates a new issue in Linear
-func (s *LinearService) CreateTicket(title, description, priority, assigneeName string) (*domain.Issue, error) {
-	if s.defaultTeam == nil {
-		return nil, fmt.Errorf("no default team available")
+// GetProjectsPage fetches one page of projects, starting after cursor. The
+// returned cursor is empty when there are no more pages.
+func (s *LinearService) GetProjectsPage(cursor string) ([]domain.Project, string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	page, err := s.client.GetProjectsPage(ctx, linear.PageOptions{First: pageSize, After: cursor})
+	if err != nil {
+		if projects, ok := s.CachedProjects(); ok && isNetworkError(err) {
+			return projects, "", nil
+		}
+		return nil, "", fmt.Errorf("failed to fetch projects from Linear API: %w", err)
+	}
+
+	return s.adapter.ConvertProjectsToUIModels(page.Nodes), nextCursor(page.PageInfo), nil
+}
+
+// GetProjectDetails fetches a project with its lead, members, milestones
+// and health, and counts its issues by workflow state
+func (s *LinearService) GetProjectDetails(projectID string) (*domain.ProjectDetails, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	project, err := s.client.GetProjectDetails(ctx, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch project from Linear API: %w", err)
+	}
+	states, err := s.client.GetProjectIssueStates(ctx, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch project issues from Linear API: %w", err)
+	}
+
+	details := s.adapter.ConvertProjectDetailsToUIModel(*project, states)
+	return &details, nil
+}
+
+// GetCycles fetches the cycles of the teams: the current cycles first, then
+// the upcoming ones soonest first, then the past ones most recent first
+func (s *LinearService) GetCycles(teamIDs []string) ([]domain.Cycle, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	cycles, err := s.client.GetCycles(ctx, teamIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch cycles from Linear API: %w", err)
 	}
 
+	uiCycles := s.adapter.ConvertCyclesToUIModels(cycles)
+	rank := map[string]int{"Current": 0, "Upcoming": 1, "Past": 2}
+	sort.SliceStable(uiCycles, func(i, j int) bool {
+		a, b := uiCycles[i], uiCycles[j]
+		if rank[a.Status] != rank[b.Status] {
+			return rank[a.Status] < rank[b.Status]
+		}
+		if a.Status == "Past" {
+			return a.StartsAt.After(b.StartsAt)
+		}
+		return a.StartsAt.Before(b.StartsAt)
+	})
+	return uiCycles, nil
+}
+
+// GetNotificationsPage fetches one page of the authenticated user's
+// notifications, newest first, starting after cursor. The returned cursor
+// is empty when there are no more pages.
+func (s *LinearService) GetNotificationsPage(cursor string) ([]domain.Notification, string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	page, err := s.client.GetNotificationsPage(ctx, linear.PageOptions{First: pageSize, After: cursor})
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to fetch notifications from Linear API: %w", err)
+	}
+
+	return s.adapter.ConvertNotificationsToUIModels(page.Nodes), nextCursor(page.PageInfo), nil
+}
+
+// MarkNotificationRead marks a notification read, or unread if read is false
+func (s *LinearService) MarkNotificationRead(notificationID string, read bool) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	input := linear.UpdateNotificationInput{ReadAt: linear.Null[time.Time]()}
+	if read {
+		input.ReadAt = linear.Set(time.Now())
+	}
+	if err := s.client.UpdateNotification(ctx, notificationID, input); err != nil {
+		return fmt.Errorf("failed to update notification: %w", err)
+	}
+	return nil
+}
+
+// SnoozeNotification hides a notification from the inbox until a time
+func (s *LinearService) SnoozeNotification(notificationID string, until time.Time) error {
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 	defer cancel()
 
-	// Convert priority string to number
-	priorityNum := s.adapter.ConvertPriorityToNumber(priority)
-
-	// Find assignee ID if specified
-	var assigneeID string
-	if assigneeName != "" && assigneeName != "Unassigned" {
-		for _, user := range s.users {
-			if user.Name == assigneeName {
-				assigneeID = user.ID
-				break
-			}
+	input := linear.UpdateNotificationInput{SnoozedUntilAt: linear.Set(until)}
+	if err := s.client.UpdateNotification(ctx, notificationID, input); err != nil {
+		return fmt.Errorf("failed to snooze notification: %w", err)
+	}
+	return nil
+}
+
+// nextCursor returns the cursor for the page after the one described by
+// pageInfo, or an empty string if it was the last page
+func nextCursor(pageInfo linear.PageInfo) string {
+	if !pageInfo.HasNextPage {
+		return ""
+	}
+	return pageInfo.EndCursor
+}
+
+// CreateTicket creates a new issue in Linear. A team is required; the
+// default team is used if none is given. While Linear cannot be reached,
+// the issue is queued in the outbox instead, and a stand-in is returned.
+func (s *LinearService) CreateTicket(issue domain.NewIssue) (*domain.Issue, error) {
+	if issue.TeamID == "" {
+		team := s.GetDefaultTeam()
+		if team == nil {
+			return nil, fmt.Errorf("no default team available")
 		}
+		issue.TeamID = team.ID
 	}
 
-	input := linear.CreateIssueInput{
-		Title:       title,
-		Description: description,
-		TeamID:      s.defaultTeam.ID,
-		Priority:    priorityNum,
-		AssigneeID:  assigneeID,
+	if s.queueing() || isLocalID(issue.ParentID) {
+		return s.queueIssue(issue)
 	}
 
-	issue, err := s.client.CreateIssue(ctx, input)
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	created, err := s.client.CreateIssue(ctx, s.adapter.ConvertNewIssueToInput(issue))
 	if err != nil {
+		if isNetworkError(err) {
+			return s.queueIssue(issue)
+		}
 		return nil, fmt.Errorf("failed to create issue: %w", err)
 	}
+	s.cache.PutIssues(*created)
+	s.saveCache()
 
 	// Convert to domain issue for UI usage
-	uiIssue := s.adapter.ConvertIssueToUIModel(*issue)
+	uiIssue := s.adapter.ConvertIssueToUIModel(*created)
 	return &uiIssue, nil
 }
 
 // GetTeams returns available teams
 func (s *LinearService) GetTeams() []linear.Team {
+	s.mu.Lock()
+	defer s.mu.Unlock()
 	return s.teams
 }
 
 // GetUsers returns available users
 func (s *LinearService) GetUsers() []linear.User {
+	s.mu.Lock()
+	defer s.mu.Unlock()
 	return s.users
 }
 
+// GetViewer returns the authenticated user
+func (s *LinearService) GetViewer() *linear.User {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.viewer
+}
+
 // GetDefaultTeam returns the default team
 func (s *LinearService) GetDefaultTeam() *linear.Team {
+	s.mu.Lock()
+	defer s.mu.Unlock()
 	return s.defaultTeam
 }
 
 // SetDefaultTeam sets the default team for operations
 func (s *LinearService) SetDefaultTeam(teamID string) error {
-	for _, team := range s.teams {
-		if team.ID == teamID {
-			s.defaultTeam = &team
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i := range s.teams {
+		if s.teams[i].ID == teamID {
+			s.defaultTeam = &s.teams[i]
 			return nil
 		}
 	}
 	return fmt.Errorf("team with ID %s not found", teamID)
 }
 
-// UpdateTicket updates an existing issue in Linear
-func (s *LinearService) UpdateTicket(issueID, title, description, priority, assigneeName, statusName string) (*domain.Issue, error) {
-	if s.defaultTeam == nil {
-		return nil, fmt.Errorf("no default team available")
+// AllTeams reports whether the issue list shows the issues of every team
+func (s *LinearService) AllTeams() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.allTeams
+}
+
+// SetAllTeams switches between showing the issues of every team and those
+// of the default team
+func (s *LinearService) SetAllTeams(allTeams bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.allTeams = allTeams
+}
+
+// UpdateTicket changes the fields of an issue set in update. While Linear
+// cannot be reached, a change of status alone is queued in the outbox
+// instead; other changes fail.
+func (s *LinearService) UpdateTicket(issueID string, update domain.IssueUpdate) (*domain.Issue, error) {
+	queueable := statusOnly(update)
+	if queueable && (s.queueing() || isLocalID(issueID)) {
+		return s.queueStatus(issueID, *update.StateID)
+	}
+	if isLocalID(issueID) {
+		return nil, fmt.Errorf("only the status of an issue created offline can be changed until it is sent to Linear")
 	}
 
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 	defer cancel()
 
-	// Build update input
-	input := linear.UpdateIssueInput{
-		Title:       title,
-		Description: description,
+	issue, err := s.client.UpdateIssue(ctx, issueID, s.adapter.ConvertIssueUpdateToInput(update))
+	if err != nil {
+		if queueable && isNetworkError(err) {
+			return s.queueStatus(issueID, *update.StateID)
+		}
+		return nil, fmt.Errorf("failed to update issue: %w", err)
 	}
+	s.cache.PutIssues(*issue)
+	s.saveCache()
 
-	// Convert priority string to number if provided
-	if priority != "" {
-		input.Priority = s.adapter.ConvertPriorityToNumber(priority)
-	}
+	// Convert to domain issue for UI usage
+	uiIssue := s.adapter.ConvertIssueToUIModel(*issue)
+	return &uiIssue, nil
+}
 
-	// Find assignee ID if specified
-	if assigneeName != "" && assigneeName != "Unassigned" {
-		for _, user := range s.users {
-			if user.Name == assigneeName {
-				input.AssigneeID = user.ID
-				break
-			}
-		}
+// ArchiveTicket archives an issue, dropping it from the cache
+func (s *LinearService) ArchiveTicket(issueID string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	if err := s.client.ArchiveIssue(ctx, issueID); err != nil {
+		return fmt.Errorf("failed to archive issue: %w", err)
 	}
+	s.cache.RemoveIssues(issueID)
+	s.saveCache()
+	return nil
+}
 
-	// Find state ID if status name provided
-	if statusName != "" {
-		states, err := s.GetIssueStates()
-		if err == nil {
-			for _, state := range states {
-				if state.Name == statusName {
-					input.StateID = state.ID
-					break
-				}
-			}
-		}
+// DeleteTicket moves an issue to the trash, dropping it from the cache
+func (s *LinearService) DeleteTicket(issueID string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	if err := s.client.DeleteIssue(ctx, issueID); err != nil {
+		return fmt.Errorf("failed to delete issue: %w", err)
 	}
+	s.cache.RemoveIssues(issueID)
+	s.saveCache()
+	return nil
+}
 
-	// Update the issue
-	issue, err := s.client.UpdateIssue(ctx, issueID, input)
-	if err != nil {
-		return nil, fmt.Errorf("failed to update issue: %w", err)
+// UnarchiveTicket restores an archived issue, or one moved to the trash,
+// and fetches it
+func (s *LinearService) UnarchiveTicket(issueID string) (*domain.Issue, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	if err := s.client.UnarchiveIssue(ctx, issueID); err != nil {
+		return nil, fmt.Errorf("failed to unarchive issue: %w", err)
 	}
+	return s.GetTicketByID(issueID)
+}
 
-	// Convert to domain issue for UI usage
-	uiIssue := s.adapter.ConvertIssueToUIModel(*issue)
-	return &uiIssue, nil
+// Queued reports whether an issue or comment was made offline and has not
+// been sent to Linear yet
+func (s *LinearService) Queued(id string) bool {
+	return isLocalID(id)
 }
 
-// RefreshData forces a refresh of cached data
+// RefreshData forces a refresh of cached data, keeping the default team.
+// Unlike a sync on its own, it fetches the teams and users again.
 func (s *LinearService) RefreshData() error {
-	return s.initialize()
+	s.mu.Lock()
+	s.workspaceFetched = false
+	s.mu.Unlock()
+	return s.Sync()
 }
 
 // IsDataStale checks if cached data should be refreshed
 func (s *LinearService) IsDataStale() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
 	return time.Since(s.lastDataFetch) > 5*time.Minute
 }
 
 // GetIssueStates fetches available issue states for the default team
 func (s *LinearService) GetIssueStates() ([]linear.IssueState, error) {
-	if s.defaultTeam == nil {
+	team := s.GetDefaultTeam()
+	if team == nil {
 		return nil, fmt.Errorf("no default team available")
 	}
 
+	return s.GetTeamIssueStates(team.ID)
+}
+
+// GetTeamIssueStates fetches the workflow states of a team. States rarely
+// change, so they are fetched once per team.
+func (s *LinearService) GetTeamIssueStates(teamID string) ([]linear.IssueState, error) {
+	s.mu.Lock()
+	states, ok := s.issueStates[teamID]
+	s.mu.Unlock()
+	if ok {
+		return states, nil
+	}
+	if states, ok := s.cache.States(teamID); ok {
+		s.mu.Lock()
+		s.issueStates[teamID] = states
+		s.mu.Unlock()
+		return states, nil
+	}
+
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 	defer cancel()
 
-	states, err := s.client.GetIssueStates(ctx, s.defaultTeam.ID)
+	states, err := s.client.GetIssueStates(ctx, teamID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch issue states: %w", err)
 	}
 
+	s.mu.Lock()
+	s.issueStates[teamID] = states
+	s.mu.Unlock()
+	s.cache.SetStates(teamID, states)
 	return states, nil
 }
+
+// GetTeamLabels fetches the labels available to issues of a team, which
+// include the workspace labels. Like states, they are fetched once per team.
+func (s *LinearService) GetTeamLabels(teamID string) ([]linear.IssueLabel, error) {
+	s.mu.Lock()
+	labels, ok := s.labels[teamID]
+	s.mu.Unlock()
+	if ok {
+		return labels, nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	labels, err := s.client.GetIssueLabels(ctx, teamID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch labels: %w", err)
+	}
+
+	s.mu.Lock()
+	s.labels[teamID] = labels
+	s.mu.Unlock()
+	return labels, nil
+}
@@ -0,0 +1,466 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/linear-tui/linear-tui/internal/cache"
+	"github.com/linear-tui/linear-tui/internal/domain"
+	"github.com/linear-tui/linear-tui/internal/linear"
+)
+
+// localIDPrefix starts the IDs given to issues created offline until Linear
+// gives them theirs
+const localIDPrefix = "local-"
+
+// newLocalID returns a new ID for an issue created offline or a queued
+// edit: a random UUID, which an issue created offline is created with
+func newLocalID() string {
+	var b [16]byte
+	_, _ = rand.Read(b[:])
+	b[6] = b[6]&0x0f | 0x40
+	b[8] = b[8]&0x3f | 0x80
+	return fmt.Sprintf("%s%x-%x-%x-%x-%x", localIDPrefix, b[0:4], b[4:6], b[6:8], b[8:10], b[10:])
+}
+
+// isLocalID reports whether id is that of an issue created offline
+func isLocalID(id string) bool {
+	return strings.HasPrefix(id, localIDPrefix)
+}
+
+// isNetworkError reports whether err is a failure to reach Linear
+func isNetworkError(err error) bool {
+	var linearErr *linear.LinearError
+	return errors.As(err, &linearErr) && linearErr.Type == linear.ErrorTypeNetwork
+}
+
+// statusOnly reports whether update changes the status of an issue and
+// nothing else, the one change that can be made offline
+func statusOnly(update domain.IssueUpdate) bool {
+	return update.StateID != nil && update.Title == nil && update.Description == nil &&
+		update.Priority == nil && update.AssigneeID == nil && update.ProjectID == nil &&
+		update.CycleID == nil && update.LabelIDs == nil
+}
+
+// Offline reports whether Linear could not be reached on the last request
+func (s *LinearService) Offline() bool {
+	return !s.client.Reachable()
+}
+
+// QueuedEdits returns the number of edits waiting in the outbox
+func (s *LinearService) QueuedEdits() int {
+	return s.outbox.Len()
+}
+
+// queueing reports whether edits go to the outbox rather than to Linear:
+// while Linear cannot be reached, and until the edits queued earlier have
+// been sent, so that they reach Linear in the order they were made
+func (s *LinearService) queueing() bool {
+	return s.Offline() || s.outbox.Len() > 0
+}
+
+// offlineTickets returns the cached issues matching filter in place of a
+// list that could not be fetched. ok is false unless err is a failure to
+// reach Linear and the cache can be searched with the filter; unlike
+// CachedTickets, the issues may be incomplete.
+func (s *LinearService) offlineTickets(filter linear.IssueFilter, err error) (issues []domain.Issue, ok bool) {
+	if !isNetworkError(err) || !s.Synced() || !filter.Local() {
+		return nil, false
+	}
+	return s.matchCached(filter), true
+}
+
+// matchCached returns the cached issues matching filter, most recently
+// updated first
+func (s *LinearService) matchCached(filter linear.IssueFilter) []domain.Issue {
+	var viewerID string
+	if viewer := s.GetViewer(); viewer != nil {
+		viewerID = viewer.ID
+	}
+	var matched []linear.Issue
+	for _, issue := range s.cache.Issues() {
+		if filter.Match(issue, viewerID) {
+			matched = append(matched, issue)
+		}
+	}
+	return s.adapter.ConvertIssuesToUIModels(matched)
+}
+
+// queueStatus queues a change of the status of an issue, and shows it in
+// the cached issue until it is sent
+func (s *LinearService) queueStatus(issueID, stateID string) (*domain.Issue, error) {
+	issue, ok := s.cache.Issue(issueID)
+	if !ok || issue.Team == nil {
+		return nil, fmt.Errorf("failed to queue status change: issue %s is not cached", issueID)
+	}
+	states, err := s.GetTeamIssueStates(issue.Team.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update issue: %w", err)
+	}
+	state, ok := findState(states, stateID)
+	if !ok {
+		return nil, fmt.Errorf("failed to update issue: unknown status %s", stateID)
+	}
+
+	err = s.outbox.Add(cache.Edit{
+		ID:            newLocalID(),
+		Kind:          cache.EditStatus,
+		QueuedAt:      time.Now(),
+		IssueID:       issueID,
+		Identifier:    issue.Identifier,
+		BaseUpdatedAt: issue.UpdatedAt,
+		StateID:       state.ID,
+		StateName:     state.Name,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to queue status change: %w", err)
+	}
+
+	// The update time is kept, since Linear has not seen the change yet
+	issue.State = state
+	s.cache.PutIssues(issue)
+	s.saveCache()
+
+	uiIssue := s.adapter.ConvertIssueToUIModel(issue)
+	return &uiIssue, nil
+}
+
+// reapplyQueued shows the status changes still queued in the cached issues
+// they belong to, once a sync has replaced them with Linear's copy. A
+// change whose issue has since been updated on Linear is left out, as it
+// will be dropped as a conflict when sent.
+func (s *LinearService) reapplyQueued() {
+	for _, edit := range s.outbox.Edits() {
+		if edit.Kind != cache.EditStatus {
+			continue
+		}
+		issue, ok := s.cache.Issue(edit.IssueID)
+		if !ok || issue.Team == nil || issue.UpdatedAt.After(edit.BaseUpdatedAt) {
+			continue
+		}
+		states, _ := s.cache.States(issue.Team.ID)
+		if state, ok := findState(states, edit.StateID); ok {
+			issue.State = state
+			s.cache.PutIssues(issue)
+		}
+	}
+}
+
+// queueComment queues a comment on an issue
+func (s *LinearService) queueComment(issueID, body string) (*domain.Comment, error) {
+	edit := cache.Edit{
+		ID:       newLocalID(),
+		Kind:     cache.EditComment,
+		QueuedAt: time.Now(),
+		IssueID:  issueID,
+		Body:     body,
+	}
+	if issue, ok := s.cache.Issue(issueID); ok {
+		edit.Identifier = issue.Identifier
+	}
+	if err := s.outbox.Add(edit); err != nil {
+		return nil, fmt.Errorf("failed to queue comment: %w", err)
+	}
+
+	comment := s.queuedComment(edit)
+	return &comment, nil
+}
+
+// queuedComments returns the comments on an issue waiting in the outbox
+func (s *LinearService) queuedComments(issueID string) []domain.Comment {
+	var comments []domain.Comment
+	for _, edit := range s.outbox.Edits() {
+		if edit.Kind == cache.EditComment && edit.IssueID == issueID {
+			comments = append(comments, s.queuedComment(edit))
+		}
+	}
+	return comments
+}
+
+// queuedComment returns the comment a queued edit will post
+func (s *LinearService) queuedComment(edit cache.Edit) domain.Comment {
+	author := "You"
+	if viewer := s.GetViewer(); viewer != nil {
+		author = viewer.Name
+	}
+	return domain.Comment{
+		ID:        edit.ID,
+		Body:      edit.Body,
+		Author:    author,
+		CreatedAt: edit.QueuedAt,
+		UpdatedAt: edit.QueuedAt,
+		Queued:    true,
+	}
+}
+
+// queueIssue queues the creation of an issue, and caches a stand-in for it
+// with a local ID, so that it is listed and can be commented on and moved
+// until it is created
+func (s *LinearService) queueIssue(newIssue domain.NewIssue) (*domain.Issue, error) {
+	issue := s.standIn(newIssue)
+	err := s.outbox.Add(cache.Edit{
+		ID:         issue.ID,
+		Kind:       cache.EditCreate,
+		QueuedAt:   issue.CreatedAt,
+		Identifier: issue.Identifier,
+		Issue:      &newIssue,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to queue issue: %w", err)
+	}
+
+	s.cache.PutIssues(issue)
+	s.saveCache()
+
+	uiIssue := s.adapter.ConvertIssueToUIModel(issue)
+	return &uiIssue, nil
+}
+
+// standIn builds the issue shown for an issue created offline from what is
+// known locally about its team, status, assignee, project, labels and
+// parent
+func (s *LinearService) standIn(newIssue domain.NewIssue) linear.Issue {
+	now := time.Now()
+	issue := linear.Issue{
+		ID:          newLocalID(),
+		Title:       newIssue.Title,
+		Description: newIssue.Description,
+		Priority:    newIssue.Priority,
+		Creator:     s.GetViewer(),
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+
+	for _, team := range s.GetTeams() {
+		if team.ID == newIssue.TeamID {
+			issue.Team = &team
+			issue.Identifier = team.Key + "-new"
+			break
+		}
+	}
+
+	if states, err := s.GetTeamIssueStates(newIssue.TeamID); err == nil {
+		if state, ok := findState(states, newIssue.StateID); ok {
+			issue.State = state
+		} else {
+			issue.State = defaultState(states)
+		}
+	}
+
+	for _, user := range s.GetUsers() {
+		if user.ID == newIssue.AssigneeID {
+			issue.Assignee = &user
+			break
+		}
+	}
+
+	for _, project := range s.cache.Projects() {
+		if project.ID == newIssue.ProjectID {
+			issue.Project = &linear.Project{ID: project.ID, Name: project.Name}
+			break
+		}
+	}
+
+	s.mu.Lock()
+	labels := s.labels[newIssue.TeamID]
+	s.mu.Unlock()
+	for _, label := range labels {
+		for _, labelID := range newIssue.LabelIDs {
+			if label.ID == labelID {
+				issue.Labels.Nodes = append(issue.Labels.Nodes, label)
+			}
+		}
+	}
+
+	if parent, ok := s.cache.Issue(newIssue.ParentID); ok {
+		issue.Parent = &linear.Issue{ID: parent.ID, Identifier: parent.Identifier, Title: parent.Title}
+	}
+	return issue
+}
+
+// findState returns the state with ID stateID
+func findState(states []linear.IssueState, stateID string) (linear.IssueState, bool) {
+	for _, state := range states {
+		if state.ID == stateID {
+			return state, true
+		}
+	}
+	return linear.IssueState{}, false
+}
+
+// defaultState guesses the state Linear gives new issues: the first
+// backlog state, or else the first unstarted one
+func defaultState(states []linear.IssueState) linear.IssueState {
+	for _, stateType := range []string{"backlog", "unstarted"} {
+		var first *linear.IssueState
+		for i := range states {
+			if states[i].Type == stateType && (first == nil || states[i].Position < first.Position) {
+				first = &states[i]
+			}
+		}
+		if first != nil {
+			return *first
+		}
+	}
+	return linear.IssueState{}
+}
+
+// OutboxReport describes the sending of the outbox
+type OutboxReport struct {
+	Sent int
+
+	// Dropped describes each edit that was not sent and was dropped from
+	// the outbox, because it conflicted with a change made on Linear or
+	// Linear refused it
+	Dropped []string
+}
+
+// ReplayOutbox sends the queued edits to Linear in the order they were
+// made. A status change is dropped if the issue was updated on Linear
+// since the change was made, as the change was made without seeing the
+// update; comments and new issues overwrite nothing and are always sent.
+// Sending stops at the first failure to reach Linear, keeping the edits
+// not yet sent for the next time.
+func (s *LinearService) ReplayOutbox() (OutboxReport, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+	defer cancel()
+
+	var report OutboxReport
+	drop := func(edit cache.Edit, reason string) {
+		report.Dropped = append(report.Dropped, fmt.Sprintf("%s: %s", describeEdit(edit), reason))
+		_ = s.outbox.Remove(edit.ID)
+	}
+
+	for {
+		edits := s.outbox.Edits()
+		if len(edits) == 0 {
+			break
+		}
+		edit := edits[0]
+
+		// Edits of issues created offline are pointed at the created issues
+		// as they are sent, so those still pointing at a local ID belong to
+		// an issue that could not be created
+		dependsOn := edit.IssueID
+		if edit.Issue != nil {
+			dependsOn = edit.Issue.ParentID
+		}
+		if isLocalID(dependsOn) {
+			drop(edit, "the issue it belongs to was not created")
+			continue
+		}
+
+		err := s.replay(ctx, edit)
+		var conflict *conflictError
+		switch {
+		case err == nil:
+			report.Sent++
+			_ = s.outbox.Remove(edit.ID)
+		case isNetworkError(err):
+			s.saveCache()
+			return report, fmt.Errorf("failed to send queued edits: %w", err)
+		case errors.As(err, &conflict):
+			drop(edit, conflict.Error())
+		default:
+			if edit.Kind == cache.EditCreate {
+				s.cache.RemoveIssues(edit.ID)
+			}
+			drop(edit, err.Error())
+		}
+	}
+
+	s.saveCache()
+	return report, nil
+}
+
+// conflictError is returned for a status change made on an issue that has
+// since been updated on Linear
+type conflictError struct {
+	updatedAt time.Time
+}
+
+func (e *conflictError) Error() string {
+	return "the issue was changed on Linear at " + e.updatedAt.Local().Format("Jan 02 15:04") + ", after the edit was made"
+}
+
+// replay sends a queued edit to Linear and caches the result
+func (s *LinearService) replay(ctx context.Context, edit cache.Edit) error {
+	switch edit.Kind {
+	case cache.EditCreate:
+		// The issue is created with the UUID of its local ID, so that if it
+		// was created by an earlier attempt whose response was lost, Linear
+		// refuses to create it again and the existing issue is taken
+		id := strings.TrimPrefix(edit.ID, localIDPrefix)
+		input := s.adapter.ConvertNewIssueToInput(*edit.Issue)
+		input.ID = linear.Set(id)
+		created, err := s.client.CreateIssue(ctx, input)
+		if err != nil && !isNetworkError(err) {
+			if existing, getErr := s.client.GetIssueByID(ctx, id); getErr == nil {
+				created, err = existing, nil
+			}
+		}
+		if err != nil {
+			return err
+		}
+		s.cache.RemoveIssues(edit.ID)
+		s.cache.PutIssues(*created)
+		if err := s.outbox.Reassign(edit.ID, created.ID, created.Identifier); err != nil {
+			return err
+		}
+		return s.outbox.Rebase(created.ID, created.UpdatedAt)
+
+	case cache.EditStatus:
+		current, err := s.client.GetIssueByID(ctx, edit.IssueID)
+		if err != nil {
+			return err
+		}
+		if current.UpdatedAt.After(edit.BaseUpdatedAt) {
+			s.cache.PutIssues(*current)
+			return &conflictError{updatedAt: current.UpdatedAt}
+		}
+
+		stateID := edit.StateID
+		updated, err := s.client.UpdateIssue(ctx, edit.IssueID, s.adapter.ConvertIssueUpdateToInput(domain.IssueUpdate{StateID: &stateID}))
+		if err != nil {
+			return err
+		}
+		s.cache.PutIssues(*updated)
+		return s.outbox.Rebase(updated.ID, updated.UpdatedAt)
+
+	case cache.EditComment:
+		// As for an issue, the comment is created with the UUID of its edit,
+		// so that one posted by an earlier attempt is not posted again
+		id := strings.TrimPrefix(edit.ID, localIDPrefix)
+		comment, err := s.client.CreateComment(ctx, id, edit.IssueID, edit.Body)
+		if err != nil && !isNetworkError(err) {
+			if existing, getErr := s.client.GetCommentByID(ctx, id); getErr == nil {
+				comment, err = existing, nil
+			}
+		}
+		if err != nil {
+			return err
+		}
+		if comment.Issue != nil {
+			return s.outbox.Rebase(edit.IssueID, comment.Issue.UpdatedAt)
+		}
+		return nil
+	}
+	return fmt.Errorf("unknown kind of edit %q", edit.Kind)
+}
+
+// describeEdit names a queued edit for the report of the outbox
+func describeEdit(edit cache.Edit) string {
+	switch edit.Kind {
+	case cache.EditCreate:
+		return fmt.Sprintf("new issue %q", edit.Issue.Title)
+	case cache.EditStatus:
+		return fmt.Sprintf("%s moved to %s", edit.Identifier, edit.StateName)
+	case cache.EditComment:
+		return "comment on " + edit.Identifier
+	}
+	return edit.Kind
+}
@@ -0,0 +1,220 @@
+package services
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/linear-tui/linear-tui/internal/adapters"
+	"github.com/linear-tui/linear-tui/internal/cache"
+	"github.com/linear-tui/linear-tui/internal/domain"
+	"github.com/linear-tui/linear-tui/internal/linear"
+)
+
+// fakeLinear stands in for the Linear API, holding issues and noting the
+// mutations sent to it. Each mutation of an issue moves its update time
+// on by an hour.
+type fakeLinear struct {
+	issues     map[string]linear.Issue
+	failCreate bool
+
+	// sent lists the mutations received, such as "issueUpdate ENG-1" or
+	// "issueCreate ENG-9 under <parent ID>"
+	sent []string
+}
+
+func (f *fakeLinear) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Query     string         `json:"query"`
+		Variables map[string]any `json:"variables"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	input, _ := req.Variables["input"].(map[string]any)
+	id, _ := req.Variables["id"].(string)
+
+	var data any
+	switch {
+	case strings.Contains(req.Query, "issueCreate("):
+		if f.failCreate {
+			f.reply(w, nil, "team not found")
+			return
+		}
+		id, _ := input["id"].(string)
+		issue := linear.Issue{ID: id, Identifier: "ENG-9", UpdatedAt: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}
+		f.issues[id] = issue
+		created := "issueCreate " + issue.Identifier
+		if parentID, ok := input["parentId"].(string); ok {
+			created += " under " + parentID
+		}
+		f.sent = append(f.sent, created)
+		data = map[string]any{"issueCreate": map[string]any{"success": true, "issue": issue}}
+	case strings.Contains(req.Query, "issueUpdate("):
+		issue, ok := f.issues[id]
+		if !ok {
+			f.reply(w, nil, "issue not found")
+			return
+		}
+		issue.UpdatedAt = issue.UpdatedAt.Add(time.Hour)
+		f.issues[id] = issue
+		f.sent = append(f.sent, "issueUpdate "+issue.Identifier)
+		data = map[string]any{"issueUpdate": map[string]any{"success": true, "issue": issue}}
+	case strings.Contains(req.Query, "commentCreate("):
+		issueID, _ := input["issueId"].(string)
+		issue, ok := f.issues[issueID]
+		if !ok {
+			f.reply(w, nil, "issue not found")
+			return
+		}
+		issue.UpdatedAt = issue.UpdatedAt.Add(time.Hour)
+		f.issues[issueID] = issue
+		f.sent = append(f.sent, "commentCreate "+issue.Identifier)
+		data = map[string]any{"commentCreate": map[string]any{"success": true, "comment": linear.Comment{ID: "comment", Issue: &issue}}}
+	case strings.Contains(req.Query, "issue(id"):
+		issue, ok := f.issues[id]
+		if !ok {
+			f.reply(w, nil, "issue not found")
+			return
+		}
+		data = map[string]any{"issue": issue}
+	default:
+		f.reply(w, nil, "unexpected request")
+		return
+	}
+	f.reply(w, data, "")
+}
+
+// reply writes a GraphQL response with data, or with an error if message
+// is set
+func (f *fakeLinear) reply(w http.ResponseWriter, data any, message string) {
+	response := map[string]any{"data": data}
+	if message != "" {
+		response["errors"] = []map[string]string{{"message": message}}
+	}
+	_ = json.NewEncoder(w).Encode(response)
+}
+
+// newReplayService returns a service sending to fake, with an empty cache
+// and outbox
+func newReplayService(t *testing.T, fake *fakeLinear) *LinearService {
+	t.Helper()
+	server := httptest.NewServer(fake)
+	t.Cleanup(server.Close)
+
+	client, err := linear.NewClient("key")
+	if err != nil {
+		t.Fatalf("NewClient failed: %v", err)
+	}
+	client.SetBaseURL(server.URL)
+	return &LinearService{
+		client:  client,
+		adapter: adapters.NewLinearAdapter(),
+		cache:   cache.Open(""),
+		outbox:  cache.OpenOutbox(""),
+	}
+}
+
+func TestReplayOutboxConflict(t *testing.T) {
+	seen := time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		updated time.Time
+		sent    []string
+		dropped int
+	}{
+		{"issue unchanged since the edit", seen, []string{"issueUpdate ENG-1"}, 0},
+		{"issue changed on Linear since the edit", seen.Add(time.Minute), nil, 1},
+		{"older copy on Linear", seen.Add(-time.Minute), []string{"issueUpdate ENG-1"}, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := &fakeLinear{issues: map[string]linear.Issue{
+				"issue": {ID: "issue", Identifier: "ENG-1", UpdatedAt: tt.updated},
+			}}
+			s := newReplayService(t, fake)
+			_ = s.outbox.Add(cache.Edit{
+				ID: newLocalID(), Kind: cache.EditStatus, IssueID: "issue", Identifier: "ENG-1",
+				BaseUpdatedAt: seen, StateID: "done", StateName: "Done",
+			})
+
+			report, err := s.ReplayOutbox()
+			if err != nil {
+				t.Fatalf("ReplayOutbox failed: %v", err)
+			}
+			if strings.Join(fake.sent, ", ") != strings.Join(tt.sent, ", ") {
+				t.Errorf("sent %v, want %v", fake.sent, tt.sent)
+			}
+			if report.Sent != len(tt.sent) || len(report.Dropped) != tt.dropped {
+				t.Errorf("report = %d sent, %v dropped, want %d sent, %d dropped", report.Sent, report.Dropped, len(tt.sent), tt.dropped)
+			}
+			if s.outbox.Len() != 0 {
+				t.Errorf("outbox kept %d edits", s.outbox.Len())
+			}
+		})
+	}
+}
+
+func TestReplayOutboxReassign(t *testing.T) {
+	tests := []struct {
+		name       string
+		failCreate bool
+		sent       []string
+		dropped    int
+	}{
+		{
+			// The edits of the issue follow it to the ID Linear gave it,
+			// and are not taken for conflicts with its creation
+			name: "edits follow the created issue",
+			sent: []string{"issueCreate ENG-9", "issueUpdate ENG-9", "commentCreate ENG-9", "issueUpdate ENG-9"},
+		},
+		{
+			name:       "edits of an issue not created are dropped",
+			failCreate: true,
+			dropped:    5,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := &fakeLinear{issues: map[string]linear.Issue{}, failCreate: tt.failCreate}
+			s := newReplayService(t, fake)
+
+			localID := newLocalID()
+			edits := []cache.Edit{
+				{ID: localID, Kind: cache.EditCreate, Issue: &domain.NewIssue{Title: "Crash", TeamID: "team"}},
+				{ID: newLocalID(), Kind: cache.EditStatus, IssueID: localID, Identifier: "new issue", StateID: "started", StateName: "In Progress"},
+				{ID: newLocalID(), Kind: cache.EditComment, IssueID: localID, Identifier: "new issue", Body: "Seen on Linux"},
+				{ID: newLocalID(), Kind: cache.EditStatus, IssueID: localID, Identifier: "new issue", StateID: "done", StateName: "Done"},
+				// A sub-issue of the issue, which is created under it
+				{ID: newLocalID(), Kind: cache.EditCreate, Issue: &domain.NewIssue{Title: "Trace", TeamID: "team", ParentID: localID}},
+			}
+			for _, edit := range edits {
+				_ = s.outbox.Add(edit)
+			}
+			if !tt.failCreate {
+				tt.sent = append(tt.sent, "issueCreate ENG-9 under "+strings.TrimPrefix(localID, localIDPrefix))
+			}
+
+			report, err := s.ReplayOutbox()
+			if err != nil {
+				t.Fatalf("ReplayOutbox failed: %v", err)
+			}
+			if strings.Join(fake.sent, ", ") != strings.Join(tt.sent, ", ") {
+				t.Errorf("sent %v, want %v", fake.sent, tt.sent)
+			}
+			if report.Sent != len(tt.sent) || len(report.Dropped) != tt.dropped {
+				t.Errorf("report = %d sent, %v dropped, want %d sent, %d dropped", report.Sent, report.Dropped, len(tt.sent), tt.dropped)
+			}
+			if _, ok := s.cache.Issue(localID); ok {
+				t.Error("the issue is still cached under its local ID")
+			}
+		})
+	}
+}
@@ -0,0 +1,140 @@
+package services
+
+import (
+	"sync"
+
+	"github.com/linear-tui/linear-tui/internal/domain"
+	"github.com/linear-tui/linear-tui/internal/linear"
+)
+
+// ledger records the updates of each issue that have been shown but not
+// yet confirmed by Linear, so that an issue is shown as Linear last
+// returned it with the updates still in flight applied on top. Updates
+// finishing in any order, or failing, then leave the issue shown as it
+// will end up.
+type ledger struct {
+	mu      sync.Mutex
+	seq     int
+	entries map[string]*ledgerEntry
+}
+
+// ledgerEntry holds the updates in flight for an issue, oldest first, and
+// the issue as last confirmed
+type ledgerEntry struct {
+	confirmed domain.Issue
+	pending   []pendingUpdate
+}
+
+type pendingUpdate struct {
+	seq    int
+	update domain.IssueUpdate
+}
+
+// BeginUpdate records an update of an issue about to be sent, and returns
+// the issue as it is shown until the update finishes, and the number that
+// identifies the update to FinishUpdate. Only the status, priority and
+// assignee are shown before Linear confirms them.
+func (s *LinearService) BeginUpdate(issue domain.Issue, update domain.IssueUpdate) (shown domain.Issue, seq int) {
+	s.ledger.mu.Lock()
+	defer s.ledger.mu.Unlock()
+
+	if s.ledger.entries == nil {
+		s.ledger.entries = make(map[string]*ledgerEntry)
+	}
+	entry, ok := s.ledger.entries[issue.LinearID]
+	if !ok {
+		entry = &ledgerEntry{confirmed: issue}
+		s.ledger.entries[issue.LinearID] = entry
+	}
+
+	s.ledger.seq++
+	entry.pending = append(entry.pending, pendingUpdate{seq: s.ledger.seq, update: update})
+	return s.applyPending(entry), s.ledger.seq
+}
+
+// FinishUpdate records that the update seq of an issue has finished, with
+// the issue Linear returned, or nil if it failed. It returns the issue as
+// it is shown from then on: a failed update is undone, while the updates
+// still in flight stay applied. ok is false if the update was not begun
+// and failed, leaving nothing to show.
+func (s *LinearService) FinishUpdate(issueID string, seq int, updated *domain.Issue) (shown domain.Issue, ok bool) {
+	s.ledger.mu.Lock()
+	defer s.ledger.mu.Unlock()
+
+	entry, ok := s.ledger.entries[issueID]
+	if !ok {
+		if updated != nil {
+			return *updated, true
+		}
+		return domain.Issue{}, false
+	}
+
+	for i, pending := range entry.pending {
+		if pending.seq == seq {
+			entry.pending = append(entry.pending[:i], entry.pending[i+1:]...)
+			break
+		}
+	}
+	// Responses may arrive out of order, so an older copy is ignored
+	if updated != nil && !updated.UpdatedAt.Before(entry.confirmed.UpdatedAt) {
+		entry.confirmed = *updated
+	}
+
+	shown = s.applyPending(entry)
+	if len(entry.pending) == 0 {
+		delete(s.ledger.entries, issueID)
+	}
+	return shown, true
+}
+
+// applyPending returns the confirmed issue of an entry with its pending
+// updates applied. The caller holds s.ledger.mu.
+func (s *LinearService) applyPending(entry *ledgerEntry) domain.Issue {
+	issue := entry.confirmed
+	for _, pending := range entry.pending {
+		issue = s.applyUpdate(issue, pending.update)
+	}
+	return issue
+}
+
+// applyUpdate returns an issue with the status, priority and assignee set
+// in update, as far as they can be named without asking Linear
+func (s *LinearService) applyUpdate(issue domain.Issue, update domain.IssueUpdate) domain.Issue {
+	if update.StateID != nil {
+		if state, ok := findState(s.knownStates(issue.TeamID), *update.StateID); ok {
+			issue.StateID = state.ID
+			issue.Status = state.Name
+			issue.StateType = state.Type
+		}
+	}
+
+	if update.Priority != nil {
+		issue.PriorityNumber = *update.Priority
+		issue.Priority = s.adapter.ConvertPriorityToString(*update.Priority)
+	}
+
+	if update.AssigneeID != nil {
+		issue.AssigneeID = *update.AssigneeID
+		issue.Assignee = "Unassigned"
+		for _, user := range s.GetUsers() {
+			if user.ID == *update.AssigneeID {
+				issue.Assignee = user.Name
+				break
+			}
+		}
+	}
+	return issue
+}
+
+// knownStates returns the workflow states of a team that have been fetched
+// or cached, without fetching them
+func (s *LinearService) knownStates(teamID string) []linear.IssueState {
+	s.mu.Lock()
+	states, ok := s.issueStates[teamID]
+	s.mu.Unlock()
+	if ok {
+		return states
+	}
+	states, _ = s.cache.States(teamID)
+	return states
+}
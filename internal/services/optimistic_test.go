@@ -0,0 +1,127 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/linear-tui/linear-tui/internal/adapters"
+	"github.com/linear-tui/linear-tui/internal/cache"
+	"github.com/linear-tui/linear-tui/internal/domain"
+	"github.com/linear-tui/linear-tui/internal/linear"
+)
+
+// ledgerStep begins an update setting the status of the issue, or finishes
+// the update begun by step finish, with Linear's copy at the given status
+// or, if failed, with none
+type ledgerStep struct {
+	begin   string
+	finish  int
+	updated string
+	failed  bool
+}
+
+func TestLedger(t *testing.T) {
+	tests := []struct {
+		name  string
+		steps []ledgerStep
+		want  []string
+	}{
+		{
+			name:  "update shown until confirmed",
+			steps: []ledgerStep{{begin: "started"}, {finish: 0, updated: "started"}},
+			want:  []string{"In Progress", "In Progress"},
+		},
+		{
+			name:  "failed update reverted",
+			steps: []ledgerStep{{begin: "started"}, {finish: 0, failed: true}},
+			want:  []string{"In Progress", "Todo"},
+		},
+		{
+			name: "failed update under one still in flight",
+			steps: []ledgerStep{
+				{begin: "started"}, {begin: "done"},
+				{finish: 0, failed: true}, {finish: 1, updated: "done"},
+			},
+			want: []string{"In Progress", "Done", "Done", "Done"},
+		},
+		{
+			name: "updates finishing out of order",
+			steps: []ledgerStep{
+				{begin: "started"}, {begin: "done"},
+				{finish: 1, updated: "done"}, {finish: 0, updated: "started"},
+			},
+			want: []string{"In Progress", "Done", "In Progress", "Done"},
+		},
+		{
+			name: "later update failing",
+			steps: []ledgerStep{
+				{begin: "started"}, {begin: "done"},
+				{finish: 1, failed: true}, {finish: 0, updated: "started"},
+			},
+			want: []string{"In Progress", "Done", "In Progress", "In Progress"},
+		},
+	}
+
+	states := []linear.IssueState{
+		{ID: "todo", Name: "Todo", Type: linear.StateTypeUnstarted},
+		{ID: "started", Name: "In Progress", Type: linear.StateTypeStarted},
+		{ID: "done", Name: "Done", Type: linear.StateTypeCompleted},
+	}
+	issue := domain.Issue{LinearID: "issue", ID: "ENG-1", TeamID: "team", StateID: "todo", Status: "Todo"}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &LinearService{
+				adapter:     adapters.NewLinearAdapter(),
+				issueStates: map[string][]linear.IssueState{"team": states},
+				cache:       cache.Open(""),
+			}
+
+			var seqs []int
+			for i, step := range tt.steps {
+				var shown domain.Issue
+				if step.begin != "" {
+					var seq int
+					shown, seq = s.BeginUpdate(issue, domain.IssueUpdate{StateID: &step.begin})
+					seqs = append(seqs, seq)
+				} else {
+					var updated *domain.Issue
+					if !step.failed {
+						// Linear's copies are newer the later the update
+						state, _ := findState(states, step.updated)
+						updated = &domain.Issue{
+							LinearID:  issue.LinearID,
+							ID:        issue.ID,
+							TeamID:    issue.TeamID,
+							StateID:   state.ID,
+							Status:    state.Name,
+							UpdatedAt: time.Unix(int64(seqs[step.finish]), 0),
+						}
+					}
+					var ok bool
+					if shown, ok = s.FinishUpdate(issue.LinearID, seqs[step.finish], updated); !ok {
+						t.Fatalf("step %d: FinishUpdate found nothing to show", i)
+					}
+				}
+				if shown.Status != tt.want[i] {
+					t.Errorf("step %d: shown status %q, want %q", i, shown.Status, tt.want[i])
+				}
+			}
+			if len(s.ledger.entries) != 0 {
+				t.Errorf("ledger kept %d entries after every update finished", len(s.ledger.entries))
+			}
+		})
+	}
+}
+
+func TestLedgerUnknownUpdate(t *testing.T) {
+	s := &LinearService{adapter: adapters.NewLinearAdapter(), cache: cache.Open("")}
+
+	if _, ok := s.FinishUpdate("issue", 1, nil); ok {
+		t.Error("FinishUpdate of a failed update never begun found something to show")
+	}
+	updated := &domain.Issue{LinearID: "issue", Status: "Done"}
+	if shown, ok := s.FinishUpdate("issue", 1, updated); !ok || shown.Status != "Done" {
+		t.Errorf("FinishUpdate = %q, %v, want the updated issue", shown.Status, ok)
+	}
+}
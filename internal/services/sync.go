@@ -0,0 +1,169 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/linear-tui/linear-tui/internal/domain"
+	"github.com/linear-tui/linear-tui/internal/linear"
+)
+
+// syncLimit caps the issues fetched by a sync. A sync that reaches it
+// starts the cached issues over, since the changes it left out would
+// otherwise never be fetched.
+const syncLimit = linear.DefaultFetchAllLimit
+
+// Sync brings the cache up to date with Linear. The first sync fetches
+// every open issue and every project; later ones fetch only the issues
+// and projects updated since the last, including archived and deleted
+// ones, which are dropped from the cache. Each sync also refreshes the
+// cached workflow states. The API key, teams and users are checked and
+// refreshed by the first sync of a run, and again by RefreshData.
+func (s *LinearService) Sync() error {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+	defer cancel()
+
+	s.mu.Lock()
+	fetched := s.workspaceFetched
+	s.mu.Unlock()
+	if !fetched {
+		var teamID string
+		if team := s.GetDefaultTeam(); team != nil {
+			teamID = team.ID
+		}
+		if err := s.refreshWorkspace(ctx, teamID); err != nil {
+			return err
+		}
+	}
+
+	for _, teamID := range s.cache.StateTeamIDs() {
+		states, err := s.client.GetIssueStates(ctx, teamID)
+		if err != nil {
+			return fmt.Errorf("failed to fetch issue states: %w", err)
+		}
+		s.mu.Lock()
+		s.issueStates[teamID] = states
+		s.mu.Unlock()
+		s.cache.SetStates(teamID, states)
+	}
+
+	if err := s.syncIssues(ctx); err != nil {
+		return err
+	}
+	if err := s.syncProjects(ctx); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	s.lastDataFetch = time.Now()
+	s.mu.Unlock()
+	s.saveCache()
+	return nil
+}
+
+// syncIssues caches the issues changed since the issues watermark, or
+// every open issue if it is zero. A sync from scratch that reaches
+// syncLimit leaves the cache incomplete, so that lists are not shown from
+// it.
+func (s *LinearService) syncIssues(ctx context.Context) error {
+	fetch := func(watermark time.Time) ([]linear.SyncedIssue, error) {
+		filter := linear.IssueFilter{UpdatedAfter: watermark}
+		if watermark.IsZero() {
+			filter.StateTypes = linear.OpenStateTypes
+		}
+		issues, err := s.client.GetSyncedIssues(ctx, filter, !watermark.IsZero(), syncLimit)
+		if err != nil {
+			return nil, fmt.Errorf("failed to sync issues: %w", err)
+		}
+		return issues, nil
+	}
+
+	watermark, _ := s.cache.Watermarks()
+	issues, err := fetch(watermark)
+	if err != nil {
+		return err
+	}
+	if !watermark.IsZero() && len(issues) >= syncLimit {
+		s.cache.ResetIssues()
+		watermark = time.Time{}
+		if issues, err = fetch(watermark); err != nil {
+			return err
+		}
+	}
+	if watermark.IsZero() {
+		s.cache.SetIssuesComplete(len(issues) < syncLimit)
+	}
+
+	// A first sync of a workspace without open issues has no update time
+	// to start from, so the watermark stays zero and the next sync starts
+	// from scratch again
+	s.cache.ApplyIssues(issues)
+	// The issues synced are as Linear has them, without the status changes
+	// queued while it could not be reached
+	s.reapplyQueued()
+	return nil
+}
+
+// syncProjects caches the projects changed since the projects watermark,
+// or every project if it is zero
+func (s *LinearService) syncProjects(ctx context.Context) error {
+	_, watermark := s.cache.Watermarks()
+	projects, err := s.client.GetSyncedProjects(ctx, watermark)
+	if err != nil {
+		return fmt.Errorf("failed to sync projects: %w", err)
+	}
+	s.cache.ApplyProjects(projects)
+	return nil
+}
+
+// saveCache writes the cache to disk. The cache only saves fetching, so
+// failing to save it is not an error.
+func (s *LinearService) saveCache() {
+	_ = s.cache.Save()
+}
+
+// Synced reports whether issues have been synced into the cache, so that
+// it can be browsed offline
+func (s *LinearService) Synced() bool {
+	issuesWatermark, _ := s.cache.Watermarks()
+	return !issuesWatermark.IsZero() || s.cache.IssuesComplete()
+}
+
+// CachedTickets returns the cached issues matching filter, most recently
+// updated first as Linear lists them. ok is false unless the cache holds
+// every issue the filter can match: every open issue must have been
+// synced, and the filter must be evaluable locally and select open issues
+// only, since closed issues are cached only once they change.
+func (s *LinearService) CachedTickets(filter linear.IssueFilter) (issues []domain.Issue, ok bool) {
+	if !s.cache.IssuesComplete() || !filter.Local() || len(filter.StateTypes) == 0 {
+		return nil, false
+	}
+	for _, stateType := range filter.StateTypes {
+		if !slices.Contains(linear.OpenStateTypes, stateType) {
+			return nil, false
+		}
+	}
+
+	return s.matchCached(filter), true
+}
+
+// CachedProjects returns the cached projects, most recently updated first.
+// ok is false until they have been synced.
+func (s *LinearService) CachedProjects() (projects []domain.Project, ok bool) {
+	if _, watermark := s.cache.Watermarks(); watermark.IsZero() {
+		return nil, false
+	}
+	return s.adapter.ConvertProjectsToUIModels(s.cache.Projects()), true
+}
+
+// CachedTicket returns a cached issue
+func (s *LinearService) CachedTicket(issueID string) (*domain.Issue, bool) {
+	issue, ok := s.cache.Issue(issueID)
+	if !ok {
+		return nil, false
+	}
+	uiIssue := s.adapter.ConvertIssueToUIModel(issue)
+	return &uiIssue, true
+}
@@ -0,0 +1,87 @@
+package ui
+
+import (
+	"sort"
+
+	"github.com/linear-tui/linear-tui/internal/linear"
+	"github.com/linear-tui/linear-tui/internal/ui/components/board"
+)
+
+// stateTypeNames are the column names of a board of several teams, which
+// has a column per state type
+var stateTypeNames = map[string]string{
+	"triage":    "Triage",
+	"backlog":   "Backlog",
+	"unstarted": "Todo",
+	"started":   "In Progress",
+	"completed": "Done",
+	"canceled":  "Canceled",
+}
+
+// boardColumns returns the board columns for the workflow states of the
+// teams on the board, which are keyed by team ID. A single team gets a column per
+// state, ordered by state type and then by their position within the type.
+// Several teams get a column per state type, since their states differ.
+func boardColumns(teamIDs []string, teamStates map[string][]linear.IssueState) []board.Column {
+	if len(teamIDs) == 1 {
+		teamID := teamIDs[0]
+		sorted := sortStates(teamStates[teamID])
+		columns := make([]board.Column, len(sorted))
+		for i, state := range sorted {
+			columns[i] = board.Column{
+				Name:     state.Name,
+				Type:     state.Type,
+				Color:    state.Color,
+				StateIDs: []string{state.ID},
+				Targets:  map[string]string{teamID: state.ID},
+			}
+		}
+		return columns
+	}
+
+	var columns []board.Column
+	index := make(map[string]int)
+	for _, teamID := range teamIDs {
+		// Cards moved into a column go to the team's first state of its type
+		for _, state := range sortStates(teamStates[teamID]) {
+			i, ok := index[state.Type]
+			if !ok {
+				name := stateTypeNames[state.Type]
+				if name == "" {
+					name = state.Type
+				}
+				i = len(columns)
+				index[state.Type] = i
+				columns = append(columns, board.Column{
+					Name:    name,
+					Type:    state.Type,
+					Color:   state.Color,
+					Targets: make(map[string]string),
+				})
+			}
+			columns[i].StateIDs = append(columns[i].StateIDs, state.ID)
+			if _, ok := columns[i].Targets[teamID]; !ok {
+				columns[i].Targets[teamID] = state.ID
+			}
+		}
+	}
+
+	sort.SliceStable(columns, func(i, j int) bool {
+		return linear.StateTypeOrder(columns[i].Type) < linear.StateTypeOrder(columns[j].Type)
+	})
+	return columns
+}
+
+// sortStates returns states ordered by state type and then by their
+// position within the type
+func sortStates(states []linear.IssueState) []linear.IssueState {
+	sorted := append([]linear.IssueState{}, states...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		a, b := linear.StateTypeOrder(sorted[i].Type), linear.StateTypeOrder(sorted[j].Type)
+		if a != b {
+			return a < b
+		}
+		return sorted[i].Position < sorted[j].Position
+	})
+	return sorted
+}
@@ -0,0 +1,349 @@
+package ui
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/linear-tui/linear-tui/internal/domain"
+	"github.com/linear-tui/linear-tui/internal/services"
+	"github.com/linear-tui/linear-tui/internal/ui/components/picker"
+	"github.com/linear-tui/linear-tui/internal/ui/messages"
+)
+
+// bulkConcurrency is the number of requests of a bulk action sent to Linear
+// at once
+const bulkConcurrency = 4
+
+// bulkArchivePickerID identifies the confirmation of a bulk archival in
+// PickerClosedMsg
+const bulkArchivePickerID = "bulk-archive"
+
+// bulkKeys are the keys that act on the issues marked in a list rather than
+// on the issue under the cursor
+var bulkKeys = map[string]bool{"s": true, "p": true, "a": true, "P": true, "C": true, "l": true, "X": true}
+
+// bulkJob is a bulk action in progress. Each issue is updated or archived
+// by a request of its own, bulkConcurrency of them at a time.
+type bulkJob struct {
+	// task is shown next to the progress bar, such as "Setting status",
+	// done says what was done in the summary, such as "Set status of",
+	// and change names it in the undo history, such as "status"
+	task   string
+	done   string
+	change string
+
+	// view is the list the issues were marked in
+	view messages.ViewType
+
+	total    int
+	finished int
+	failures []bulkFailure
+
+	// parts are the changes made on each issue, recorded for undo as one
+	// once the action has finished
+	parts []change
+}
+
+// bulkFailure is an issue a bulk action failed on, and why
+type bulkFailure struct {
+	issue domain.Issue
+	err   error
+}
+
+// bulkReport lists the issues a bulk action failed on once it has finished.
+// marked is set if they were left marked.
+type bulkReport struct {
+	title    string
+	failures []bulkFailure
+	marked   bool
+}
+
+// bulkItemDoneMsg is sent when a bulk action has finished on one issue.
+// seq is the number the service gave update, and archive is set for an
+// archival.
+type bulkItemDoneMsg struct {
+	issue   domain.Issue
+	seq     int
+	update  domain.IssueUpdate
+	archive bool
+	updated *domain.Issue
+	err     error
+}
+
+// bulkActive reports whether the edit keys act on the issues marked in the
+// list
+func (m Model) bulkActive() bool {
+	return m.focusArea == FocusMain && m.currentView.ListsIssues() && m.listView.MarkedCount() > 0
+}
+
+// startBulk starts the bulk action of a key on the marked issues: an edit
+// of a field, or an archival once confirmed
+func (m *Model) startBulk(key string) tea.Cmd {
+	if m.bulk != nil {
+		m.footer.SetError("Wait for the bulk action in progress to finish")
+		return nil
+	}
+	if m.listView.MarkingAll() {
+		m.footer.SetError("Wait for every issue matching the filter to be selected")
+		return nil
+	}
+	issues := m.listView.MarkedIssues()
+
+	if key == "X" {
+		options := []picker.Option{
+			{ID: "archive", Label: "Archive " + pluralIssues(len(issues))},
+			{Label: "Cancel"},
+		}
+		p := picker.New(bulkArchivePickerID, "Archive · "+pluralIssues(len(issues)), options, false)
+		p.SetSize(m.pickerSize())
+		m.actionPicker = &p
+		return p.Focus()
+	}
+
+	// The status, labels and cycles picked from belong to one team
+	field := editKeys[key]
+	if field == editStatus || field == editLabels || field == editCycle {
+		for _, issue := range issues[1:] {
+			if issue.TeamID != issues[0].TeamID {
+				m.footer.SetError(fmt.Sprintf("The selected issues belong to more than one team, so their %s cannot be set together", strings.ToLower(editTitles[field])))
+				return nil
+			}
+		}
+	}
+	return m.beginEdit(&issueEdit{issue: issues[0], field: field, bulk: issues})
+}
+
+// finishBulkEdit sets the field of a bulk edit to the IDs picked on each of
+// its issues that does not have that value already. Labels added in the
+// picker are added to each issue, and those shared by all the issues that
+// were removed are removed, leaving the other labels of each issue alone.
+func (m *Model) finishBulkEdit(edit issueEdit, selected []string) tea.Cmd {
+	var shared []string
+	if edit.field == editLabels {
+		shared = labelIDs(edit.issue.Labels)
+		for _, issue := range edit.bulk[1:] {
+			others := labelIDs(issue.Labels)
+			shared = slices.DeleteFunc(shared, func(id string) bool { return !slices.Contains(others, id) })
+		}
+	}
+
+	var issues []domain.Issue
+	var updates []domain.IssueUpdate
+	for _, issue := range edit.bulk {
+		picked := selected
+		if edit.field == editLabels {
+			picked = []string{}
+			for _, id := range labelIDs(issue.Labels) {
+				if !slices.Contains(shared, id) || slices.Contains(selected, id) {
+					picked = append(picked, id)
+				}
+			}
+			for _, id := range selected {
+				if !slices.Contains(picked, id) {
+					picked = append(picked, id)
+				}
+			}
+		}
+		if update, changed := editUpdate(issue, edit.field, picked); changed {
+			issues = append(issues, issue)
+			updates = append(updates, update)
+		}
+	}
+
+	name := strings.ToLower(editTitles[edit.field])
+	if len(issues) == 0 {
+		m.listView.ClearMarks()
+		return m.footer.Toast("Nothing to change on the selected issues")
+	}
+
+	// Each issue shows its new value at once, as for a single edit
+	sem := make(chan struct{}, bulkConcurrency)
+	cmds := make([]tea.Cmd, len(issues))
+	for i, issue := range issues {
+		shown, seq := m.service.BeginUpdate(issue, updates[i])
+		m.showIssue(shown)
+		cmds[i] = bulkUpdateCmd(m.service, sem, issue, seq, updates[i])
+	}
+	m.startBulkJob(&bulkJob{task: "Setting " + name, done: "Set " + name + " of", change: name, view: m.currentView, total: len(issues)})
+	return tea.Batch(cmds...)
+}
+
+// confirmBulkArchive archives the marked issues once the archival has been
+// confirmed
+func (m *Model) confirmBulkArchive(msg messages.PickerClosedMsg) tea.Cmd {
+	if m.actionPicker == nil || msg.ID != bulkArchivePickerID {
+		return nil
+	}
+	m.actionPicker = nil
+	if msg.Canceled || len(msg.Selected) == 0 || msg.Selected[0] != "archive" {
+		return nil
+	}
+	if m.listView.MarkingAll() {
+		m.footer.SetError("Wait for every issue matching the filter to be selected")
+		return nil
+	}
+
+	issues := m.listView.MarkedIssues()
+	if len(issues) == 0 {
+		return nil
+	}
+	sem := make(chan struct{}, bulkConcurrency)
+	cmds := make([]tea.Cmd, len(issues))
+	for i, issue := range issues {
+		cmds[i] = bulkArchiveCmd(m.service, sem, issue)
+	}
+	m.startBulkJob(&bulkJob{task: "Archiving", done: "Archived", change: "archival", view: m.currentView, total: len(issues)})
+	return tea.Batch(cmds...)
+}
+
+// bulkUpdateCmd sends the update of one issue of a bulk edit once fewer
+// than bulkConcurrency requests are in flight
+func bulkUpdateCmd(service *services.LinearService, sem chan struct{}, issue domain.Issue, seq int, update domain.IssueUpdate) tea.Cmd {
+	return throttle(sem, func() tea.Msg {
+		updated, err := service.UpdateTicket(issue.LinearID, update)
+		return bulkItemDoneMsg{issue: issue, seq: seq, update: update, updated: updated, err: err}
+	})
+}
+
+// bulkArchiveCmd archives one issue of a bulk archival once fewer than
+// bulkConcurrency requests are in flight
+func bulkArchiveCmd(service *services.LinearService, sem chan struct{}, issue domain.Issue) tea.Cmd {
+	return throttle(sem, func() tea.Msg {
+		return bulkItemDoneMsg{issue: issue, archive: true, err: service.ArchiveTicket(issue.LinearID)}
+	})
+}
+
+// throttle runs cmd once fewer than bulkConcurrency commands sharing sem
+// are running
+func throttle(sem chan struct{}, cmd tea.Cmd) tea.Cmd {
+	return func() tea.Msg {
+		sem <- struct{}{}
+		defer func() { <-sem }()
+
+		return cmd()
+	}
+}
+
+func (m *Model) startBulkJob(job *bulkJob) {
+	m.bulk = job
+	m.footer.SetProgress(job.task, 0, 0, job.total)
+}
+
+// bulkItemDone shows an issue a bulk action has finished on and advances
+// the progress bar. A failed update is reverted, as for a single edit, and
+// a change made is kept to be recorded for undo with the others.
+func (m *Model) bulkItemDone(msg bulkItemDoneMsg) tea.Cmd {
+	job := m.bulk
+	if job == nil {
+		return nil
+	}
+
+	if msg.archive {
+		if msg.err == nil {
+			m.removeIssue(msg.issue.LinearID)
+			job.parts = append(job.parts, change{kind: changeArchive, issue: msg.issue, summary: "archival of " + msg.issue.ID})
+		}
+	} else {
+		shown, ok := m.service.FinishUpdate(msg.issue.LinearID, msg.seq, msg.updated)
+		if ok {
+			m.showIssue(shown)
+		}
+		if msg.err == nil {
+			job.parts = append(job.parts, change{
+				kind:    changeUpdate,
+				issue:   msg.issue,
+				before:  inverseUpdate(msg.issue, msg.update),
+				after:   msg.update,
+				summary: describeUpdate(msg.issue, shown, msg.update),
+			})
+		}
+	}
+	if msg.err != nil {
+		job.failures = append(job.failures, bulkFailure{issue: msg.issue, err: msg.err})
+	}
+
+	job.finished++
+	if job.finished < job.total {
+		m.footer.SetProgress(job.task, job.finished, len(job.failures), job.total)
+		return nil
+	}
+	return m.finishBulk()
+}
+
+// finishBulk sums up a bulk action once it has finished on every issue and
+// records the changes it made for undo as one. The issues it failed on stay
+// marked, so that it can be tried again on them, and are listed with the
+// reason each failed. The marks are left alone if another list is shown by
+// then.
+func (m *Model) finishBulk() tea.Cmd {
+	job := m.bulk
+	m.bulk = nil
+	m.footer.ClearProgress()
+	inView := m.currentView == job.view
+
+	if len(job.parts) > 0 {
+		m.changes.record(change{
+			kind:    changeBulk,
+			parts:   job.parts,
+			summary: fmt.Sprintf("%s of %s", job.change, pluralIssues(len(job.parts))),
+		})
+	}
+
+	if len(job.failures) == 0 {
+		if inView {
+			m.listView.ClearMarks()
+		}
+		return m.footer.Toast(fmt.Sprintf("%s %s", job.done, pluralIssues(job.total)))
+	}
+
+	if inView {
+		failed := make([]string, len(job.failures))
+		for i, failure := range job.failures {
+			failed[i] = failure.issue.LinearID
+		}
+		m.listView.SetMarked(failed)
+	}
+
+	succeeded := job.total - len(job.failures)
+	m.bulkReport = &bulkReport{
+		title:    fmt.Sprintf("%s %d of %s, %d failed", job.done, succeeded, pluralIssues(job.total), len(job.failures)),
+		failures: job.failures,
+		marked:   inView,
+	}
+	return nil
+}
+
+// bulkReportView renders the issues a bulk action failed on
+func (m Model) bulkReportView() string {
+	report := m.bulkReport
+	width := min(80, m.width-4) - m.styles.ActiveBorder.GetHorizontalFrameSize()
+
+	lines := []string{m.styles.StatusHigh.Render(report.title), ""}
+	limit := max(m.height-12, 1)
+	for i, failure := range report.failures {
+		if i == limit {
+			lines = append(lines, m.styles.Placeholder.Render(fmt.Sprintf("and %d more", len(report.failures)-limit)))
+			break
+		}
+		line := failure.issue.ID + "  " + explainError(failure.err)
+		lines = append(lines, lipgloss.NewStyle().Width(width).MaxHeight(1).Render(line))
+	}
+	hint := "Press any key to close"
+	if report.marked {
+		hint = "The issues that failed stay selected · press any key to close"
+	}
+	lines = append(lines, "", m.styles.Placeholder.Render(hint))
+
+	return m.styles.ActiveBorder.Render(strings.Join(lines, "\n"))
+}
+
+// pluralIssues returns a number of issues, such as "1 issue" or "3 issues"
+func pluralIssues(n int) string {
+	if n == 1 {
+		return "1 issue"
+	}
+	return fmt.Sprintf("%d issues", n)
+}
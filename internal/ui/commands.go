@@ -0,0 +1,476 @@
+package ui
+
+import (
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/linear-tui/linear-tui/internal/config"
+	"github.com/linear-tui/linear-tui/internal/domain"
+	"github.com/linear-tui/linear-tui/internal/linear"
+	"github.com/linear-tui/linear-tui/internal/query"
+	"github.com/linear-tui/linear-tui/internal/services"
+	"github.com/linear-tui/linear-tui/internal/ui/messages"
+)
+
+// serviceReadyMsg is sent once the Linear service has been initialized
+type serviceReadyMsg struct{ service *services.LinearService }
+
+// teamOptionsLoadedMsg is sent once the states and labels of a team have
+// been fetched for the issue form or an edit, or fetching them failed
+type teamOptionsLoadedMsg struct {
+	teamID string
+	states []linear.IssueState
+	labels []linear.IssueLabel
+	err    error
+}
+
+// notificationUpdatedMsg is sent when marking a notification read or unread
+// or snoozing it has finished
+type notificationUpdatedMsg struct{ err error }
+
+// issueLoadedMsg is sent once an issue has been fetched to be opened, such
+// as the one a notification is about, or fetching it failed
+type issueLoadedMsg struct {
+	issue *domain.Issue
+	err   error
+}
+
+// cyclesLoadedMsg is sent once the cycles of a team have been fetched for
+// a cycle edit, or fetching them failed
+type cyclesLoadedMsg struct {
+	teamID string
+	cycles []domain.Cycle
+	err    error
+}
+
+// issuesLinkedMsg is sent when linking an issue to another one has
+// finished
+type issuesLinkedMsg struct {
+	issueID string
+	err     error
+}
+
+// projectDetailsLoadedMsg is sent once the details of an opened project
+// have been fetched, or fetching them failed
+type projectDetailsLoadedMsg struct {
+	projectID string
+	details   *domain.ProjectDetails
+	err       error
+}
+
+// projectIssuesLoadedMsg is sent once a page of the issues of an opened
+// project has been fetched. loaded is applied only if the project is still
+// open.
+type projectIssuesLoadedMsg struct {
+	projectID string
+	loaded    tea.Msg
+}
+
+// syncedMsg is sent when a sync of the cache with Linear, and the sending
+// of the outbox that follows it, have finished. syncErr is the failure of
+// the sync and replayErr that of the sending.
+type syncedMsg struct {
+	report    services.OutboxReport
+	syncErr   error
+	replayErr error
+}
+
+// issueArchivedMsg is sent when archiving an issue has finished
+type issueArchivedMsg struct {
+	issue domain.Issue
+	err   error
+}
+
+// syncTickMsg is sent periodically to sync the cache once it is stale
+type syncTickMsg struct{}
+
+// boardStatesLoadedMsg is sent once the workflow states of the teams on the
+// board have been fetched, or fetching them failed
+type boardStatesLoadedMsg struct {
+	teamIDs []string
+	states  map[string][]linear.IssueState
+	err     error
+}
+
+// initServiceCmd creates the Linear service in the background, since
+// initialization validates the API key and fetches workspace data
+func initServiceCmd(cfg *config.Config) tea.Cmd {
+	return func() tea.Msg {
+		service, err := services.NewLinearService(cfg)
+		if err != nil {
+			return messages.ErrorMsg{Err: err}
+		}
+		return serviceReadyMsg{service: service}
+	}
+}
+
+// loadIssuesCmd fetches the page of issues matching q after cursor for an
+// issue list view. An empty cursor loads the first page. Free text in q is
+// looked up with Linear's full-text search. Terms Linear cannot evaluate
+// are matched here, so a page may hold fewer issues than were fetched. A
+// further page that fails is reported in the view, keeping the issues
+// loaded.
+func loadIssuesCmd(service *services.LinearService, view messages.ViewType, q query.Compiled, cursor string) tea.Cmd {
+	return func() tea.Msg {
+		fetch := func(cursor string) ([]domain.Issue, string, error) {
+			if q.Search != "" {
+				return service.SearchTicketsPage(q.Search, q.Filter, cursor)
+			}
+			return service.GetFilteredTicketsPage(q.Filter, cursor)
+		}
+
+		items, next, fetched, err := matchingPage(fetch, q, cursor)
+		if err != nil && (cursor != "" || fetched) {
+			return messages.DataLoadedMsg{View: view, Append: cursor != "", Err: err}
+		}
+		if err != nil {
+			return messages.ErrorMsg{Err: err}
+		}
+		return messages.DataLoadedMsg{
+			View:       view,
+			Items:      items,
+			NextCursor: next,
+			Append:     cursor != "",
+		}
+	}
+}
+
+// matchingPage fetches pages of issues from cursor on until one of them
+// holds an issue matching q, or there are no more, and returns the issues
+// of that page matching q and the cursor after it. A page whose issues the
+// terms matched here all reject would leave the list empty, with no row
+// to scroll towards the next page from. fetched is set once a page has
+// been fetched, should a later one fail.
+func matchingPage(fetch func(cursor string) ([]domain.Issue, string, error), q query.Compiled, cursor string) (items []interface{}, next string, fetched bool, err error) {
+	for {
+		issues, next, err := fetch(cursor)
+		if err != nil {
+			return nil, "", fetched, err
+		}
+		fetched = true
+
+		items = make([]interface{}, 0, len(issues))
+		for _, issue := range issues {
+			if q.Match(issue) {
+				items = append(items, issue)
+			}
+		}
+		if len(items) > 0 || next == "" {
+			return items, next, fetched, nil
+		}
+		cursor = next
+	}
+}
+
+// tagGeneration tags the DataLoadedMsg sent by cmd with the generation of
+// the query or teams it loads a view for
+func tagGeneration(cmd tea.Cmd, generation int) tea.Cmd {
+	if cmd == nil {
+		return nil
+	}
+	return func() tea.Msg {
+		msg := cmd()
+		if loaded, ok := msg.(messages.DataLoadedMsg); ok {
+			loaded.Generation = generation
+			return loaded
+		}
+		return msg
+	}
+}
+
+// loadCachedIssuesCmd shows the issues matching q in an issue list view
+// from the cache. If the cache cannot show them all, fallback is run
+// instead, if any.
+func loadCachedIssuesCmd(service *services.LinearService, view messages.ViewType, q query.Compiled, fallback tea.Cmd) tea.Cmd {
+	return func() tea.Msg {
+		var issues []domain.Issue
+		ok := false
+		if q.Search == "" {
+			issues, ok = service.CachedTickets(q.Filter)
+		}
+		if !ok {
+			if fallback == nil {
+				return nil
+			}
+			return fallback()
+		}
+
+		items := make([]interface{}, 0, len(issues))
+		for _, issue := range issues {
+			if q.Match(issue) {
+				items = append(items, issue)
+			}
+		}
+		return messages.DataLoadedMsg{View: view, Items: items}
+	}
+}
+
+// loadCachedProjectsCmd shows the projects from the cache. If they have
+// not been cached, fallback is run instead, if any.
+func loadCachedProjectsCmd(service *services.LinearService, fallback tea.Cmd) tea.Cmd {
+	return func() tea.Msg {
+		projects, ok := service.CachedProjects()
+		if !ok {
+			if fallback == nil {
+				return nil
+			}
+			return fallback()
+		}
+
+		items := make([]interface{}, len(projects))
+		for i, project := range projects {
+			items[i] = project
+		}
+		return messages.DataLoadedMsg{View: messages.ProjectView, Items: items}
+	}
+}
+
+// syncCmd brings the cache up to date with Linear, then sends the edits
+// queued while Linear could not be reached. The edits are sent even if the
+// sync failed, unless it failed to reach Linear, so that a part of the
+// sync failing does not hold them back.
+func syncCmd(service *services.LinearService) tea.Cmd {
+	return func() tea.Msg {
+		syncErr := service.Sync()
+		if syncErr != nil && service.Offline() {
+			return syncedMsg{syncErr: syncErr}
+		}
+		report, replayErr := service.ReplayOutbox()
+		return syncedMsg{report: report, syncErr: syncErr, replayErr: replayErr}
+	}
+}
+
+// syncTickCmd sends a syncTickMsg after a minute
+func syncTickCmd() tea.Cmd {
+	return tea.Tick(time.Minute, func(time.Time) tea.Msg { return syncTickMsg{} })
+}
+
+// loadProjectsCmd fetches the page of projects after cursor. An empty cursor
+// loads the first page. Like issues, a further page that fails is reported
+// in the view.
+func loadProjectsCmd(service *services.LinearService, cursor string) tea.Cmd {
+	return func() tea.Msg {
+		projects, next, err := service.GetProjectsPage(cursor)
+		if err != nil && cursor != "" {
+			return messages.DataLoadedMsg{View: messages.ProjectView, Append: true, Err: err}
+		}
+		if err != nil {
+			return messages.ErrorMsg{Err: err}
+		}
+
+		items := make([]interface{}, len(projects))
+		for i, project := range projects {
+			items[i] = project
+		}
+		return messages.DataLoadedMsg{
+			View:       messages.ProjectView,
+			Items:      items,
+			NextCursor: next,
+			Append:     cursor != "",
+		}
+	}
+}
+
+// loadNotificationsCmd fetches the page of notifications after cursor,
+// leaving out those snoozed until later. An empty cursor loads the first
+// page.
+func loadNotificationsCmd(service *services.LinearService, cursor string) tea.Cmd {
+	return func() tea.Msg {
+		notifications, next, err := service.GetNotificationsPage(cursor)
+		if err != nil {
+			return messages.DataLoadedMsg{View: messages.InboxView, Append: cursor != "", Err: err}
+		}
+
+		now := time.Now()
+		items := make([]interface{}, 0, len(notifications))
+		for _, notification := range notifications {
+			if notification.SnoozedUntil.Before(now) {
+				items = append(items, notification)
+			}
+		}
+		return messages.DataLoadedMsg{
+			View:       messages.InboxView,
+			Items:      items,
+			NextCursor: next,
+			Append:     cursor != "",
+		}
+	}
+}
+
+// loadCyclesCmd fetches the cycles of the teams shown for the Cycles view.
+// Failures are shown in the view.
+func loadCyclesCmd(service *services.LinearService, teamIDs []string) tea.Cmd {
+	return func() tea.Msg {
+		cycles, err := service.GetCycles(teamIDs)
+		if err != nil {
+			return messages.DataLoadedMsg{View: messages.CycleView, Err: err}
+		}
+
+		items := make([]interface{}, len(cycles))
+		for i, cycle := range cycles {
+			items[i] = cycle
+		}
+		return messages.DataLoadedMsg{View: messages.CycleView, Items: items}
+	}
+}
+
+// loadProjectIssuesCmd fetches the page of the issues of a project after
+// cursor, across every team
+func loadProjectIssuesCmd(service *services.LinearService, projectID, cursor string) tea.Cmd {
+	load := loadIssuesCmd(service, messages.ProjectIssuesView, projectQuery(projectID), cursor)
+	return func() tea.Msg {
+		return projectIssuesLoadedMsg{projectID: projectID, loaded: load()}
+	}
+}
+
+// loadProjectDetailsCmd fetches the details of a project shown above its
+// issues. Like comments, failures are shown in place.
+func loadProjectDetailsCmd(service *services.LinearService, projectID string) tea.Cmd {
+	return func() tea.Msg {
+		details, err := service.GetProjectDetails(projectID)
+		return projectDetailsLoadedMsg{projectID: projectID, details: details, err: err}
+	}
+}
+
+// loadTeamCyclesCmd fetches the cycles of a team for a cycle edit
+func loadTeamCyclesCmd(service *services.LinearService, teamID string) tea.Cmd {
+	return func() tea.Msg {
+		cycles, err := service.GetCycles([]string{teamID})
+		return cyclesLoadedMsg{teamID: teamID, cycles: cycles, err: err}
+	}
+}
+
+// loadMoreCmd fetches the next page for a view. q is the query of the view
+// if it lists issues.
+func loadMoreCmd(service *services.LinearService, view messages.ViewType, q query.Compiled, cursor string) tea.Cmd {
+	switch view {
+	case messages.IssueView, messages.MyIssuesView, messages.CreatedView:
+		return loadIssuesCmd(service, view, q, cursor)
+	case messages.ProjectIssuesView:
+		return loadProjectIssuesCmd(service, q.Filter.ProjectID, cursor)
+	case messages.ProjectView:
+		return loadProjectsCmd(service, cursor)
+	case messages.InboxView:
+		return loadNotificationsCmd(service, cursor)
+	}
+	return nil
+}
+
+// loadCommentsCmd fetches the comment thread of an issue. Failures are
+// reported in the result rather than as an ErrorMsg, so that they are
+// shown in the detail pane instead of replacing the whole screen.
+func loadCommentsCmd(service *services.LinearService, issueID string) tea.Cmd {
+	return func() tea.Msg {
+		comments, err := service.GetTicketComments(issueID)
+		return messages.CommentsLoadedMsg{IssueID: issueID, Comments: comments, Err: err}
+	}
+}
+
+// loadHistoryCmd fetches the history of an issue. Like comments, failures
+// are shown in the detail pane.
+func loadHistoryCmd(service *services.LinearService, issueID string) tea.Cmd {
+	return func() tea.Msg {
+		events, err := service.GetTicketHistory(issueID)
+		return messages.HistoryLoadedMsg{IssueID: issueID, Events: events, Err: err}
+	}
+}
+
+// createCommentCmd posts a comment on an issue
+func createCommentCmd(service *services.LinearService, issueID, body string) tea.Cmd {
+	return func() tea.Msg {
+		comment, err := service.CreateComment(issueID, body)
+		return messages.CommentCreatedMsg{IssueID: issueID, Comment: comment, Err: err}
+	}
+}
+
+// archiveIssueCmd archives an issue
+func archiveIssueCmd(service *services.LinearService, issue domain.Issue) tea.Cmd {
+	return func() tea.Msg {
+		return issueArchivedMsg{issue: issue, err: service.ArchiveTicket(issue.LinearID)}
+	}
+}
+
+// loadTeamOptionsCmd fetches the states and labels of a team. They are
+// cached by the service, so this is quick after the first time.
+func loadTeamOptionsCmd(service *services.LinearService, teamID string) tea.Cmd {
+	return func() tea.Msg {
+		states, err := service.GetTeamIssueStates(teamID)
+		if err != nil {
+			return teamOptionsLoadedMsg{teamID: teamID, err: err}
+		}
+		labels, err := service.GetTeamLabels(teamID)
+		return teamOptionsLoadedMsg{teamID: teamID, states: states, labels: labels, err: err}
+	}
+}
+
+// createIssueCmd creates an issue
+func createIssueCmd(service *services.LinearService, issue domain.NewIssue) tea.Cmd {
+	return func() tea.Msg {
+		created, err := service.CreateTicket(issue)
+		return messages.IssueCreatedMsg{Issue: created, Err: err}
+	}
+}
+
+// updateIssueCmd changes the fields of an issue set in update. seq is the
+// number the service gave the update in BeginUpdate.
+func updateIssueCmd(service *services.LinearService, issueID string, seq int, update domain.IssueUpdate) tea.Cmd {
+	return func() tea.Msg {
+		issue, err := service.UpdateTicket(issueID, update)
+		return messages.IssueUpdatedMsg{IssueID: issueID, Seq: seq, Issue: issue, Err: err}
+	}
+}
+
+// loadBoardCmd fetches the workflow states of the teams on the board, which
+// make up its columns
+func loadBoardCmd(service *services.LinearService, teamIDs []string) tea.Cmd {
+	return func() tea.Msg {
+		states := make(map[string][]linear.IssueState, len(teamIDs))
+		for _, teamID := range teamIDs {
+			teamStates, err := service.GetTeamIssueStates(teamID)
+			if err != nil {
+				return boardStatesLoadedMsg{teamIDs: teamIDs, err: err}
+			}
+			states[teamID] = teamStates
+		}
+		return boardStatesLoadedMsg{teamIDs: teamIDs, states: states}
+	}
+}
+
+// markNotificationReadCmd marks a notification read, or unread if read is
+// false
+func markNotificationReadCmd(service *services.LinearService, notificationID string, read bool) tea.Cmd {
+	return func() tea.Msg {
+		return notificationUpdatedMsg{err: service.MarkNotificationRead(notificationID, read)}
+	}
+}
+
+// snoozeNotificationCmd hides a notification from the inbox until a time
+func snoozeNotificationCmd(service *services.LinearService, notificationID string, until time.Time) tea.Cmd {
+	return func() tea.Msg {
+		return notificationUpdatedMsg{err: service.SnoozeNotification(notificationID, until)}
+	}
+}
+
+// loadIssueCmd fetches an issue to open it in the detail pane
+func loadIssueCmd(service *services.LinearService, issueID string) tea.Cmd {
+	return func() tea.Msg {
+		issue, err := service.GetTicketByID(issueID)
+		return issueLoadedMsg{issue: issue, err: err}
+	}
+}
+
+// loadRelationsCmd fetches the issues linked to an issue. Like comments,
+// failures are shown in the detail pane.
+func loadRelationsCmd(service *services.LinearService, issueID string) tea.Cmd {
+	return func() tea.Msg {
+		relations, err := service.GetTicketRelations(issueID)
+		return messages.RelationsLoadedMsg{IssueID: issueID, Relations: relations, Err: err}
+	}
+}
+
+// linkIssuesCmd links an issue to another one
+func linkIssuesCmd(service *services.LinearService, issueID, relatedID, relation string) tea.Cmd {
+	return func() tea.Msg {
+		return issuesLinkedMsg{issueID: issueID, err: service.LinkTickets(issueID, relatedID, relation)}
+	}
+}
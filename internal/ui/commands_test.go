@@ -0,0 +1,116 @@
+package ui
+
+import (
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/linear-tui/linear-tui/internal/domain"
+	"github.com/linear-tui/linear-tui/internal/linear"
+	"github.com/linear-tui/linear-tui/internal/query"
+)
+
+func TestMatchingPage(t *testing.T) {
+	urgent := domain.Issue{ID: "ENG-1", Priority: "Urgent"}
+	low := domain.Issue{ID: "ENG-2", Priority: "Low"}
+	failure := errors.New("connection reset")
+
+	// page is a page of issues, and the cursor after it
+	type page struct {
+		issues []domain.Issue
+		next   string
+		err    error
+	}
+
+	tests := []struct {
+		name    string
+		pages   map[string]page
+		want    []string
+		next    string
+		fetched bool
+		err     error
+		calls   int
+	}{
+		{
+			name:    "matching first page",
+			pages:   map[string]page{"": {issues: []domain.Issue{urgent, low}, next: "b"}},
+			want:    []string{"ENG-2"},
+			next:    "b",
+			fetched: true,
+			calls:   1,
+		},
+		{
+			name: "filtered page that comes back empty",
+			pages: map[string]page{
+				"":  {issues: []domain.Issue{urgent}, next: "b"},
+				"b": {issues: []domain.Issue{urgent}, next: "c"},
+				"c": {issues: []domain.Issue{urgent, low}, next: "d"},
+			},
+			want:    []string{"ENG-2"},
+			next:    "d",
+			fetched: true,
+			calls:   3,
+		},
+		{
+			name: "no issue matching on any page",
+			pages: map[string]page{
+				"":  {issues: []domain.Issue{urgent}, next: "b"},
+				"b": {issues: []domain.Issue{urgent}},
+			},
+			fetched: true,
+			calls:   2,
+		},
+		{
+			name:  "first page failing",
+			pages: map[string]page{"": {err: failure}},
+			err:   failure,
+			calls: 1,
+		},
+		{
+			name: "later page failing",
+			pages: map[string]page{
+				"":  {issues: []domain.Issue{urgent}, next: "b"},
+				"b": {err: failure},
+			},
+			fetched: true,
+			err:     failure,
+			calls:   2,
+		},
+	}
+
+	q, err := query.Parse("-priority:urgent")
+	if err != nil {
+		t.Fatal(err)
+	}
+	compiled, err := query.Compile(q, linear.IssueFilter{}, nil, time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calls := 0
+			fetch := func(cursor string) ([]domain.Issue, string, error) {
+				calls++
+				p := tt.pages[cursor]
+				return p.issues, p.next, p.err
+			}
+
+			items, next, fetched, err := matchingPage(fetch, compiled, "")
+			var ids []string
+			for _, item := range items {
+				ids = append(ids, item.(domain.Issue).ID)
+			}
+			if !slices.Equal(ids, tt.want) {
+				t.Errorf("items = %v, want %v", ids, tt.want)
+			}
+			if next != tt.next || fetched != tt.fetched || !errors.Is(err, tt.err) {
+				t.Errorf("next, fetched, err = %q, %v, %v, want %q, %v, %v", next, fetched, err, tt.next, tt.fetched, tt.err)
+			}
+			if calls != tt.calls {
+				t.Errorf("fetched %d pages, want %d", calls, tt.calls)
+			}
+		})
+	}
+}
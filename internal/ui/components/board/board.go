@@ -0,0 +1,424 @@
+package board
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/linear-tui/linear-tui/internal/domain"
+	"github.com/linear-tui/linear-tui/internal/ui/messages"
+	"github.com/mattn/go-runewidth"
+)
+
+// Column is a column of the board, holding the issues in one or more
+// workflow states. A board of a single team has a column per state; a board
+// of several teams has a column per state type, holding the states of that
+// type of every team.
+type Column struct {
+	Name  string
+	Type  string
+	Color string
+
+	// StateIDs are the states whose issues the column holds
+	StateIDs []string
+
+	// Targets maps the ID of each team to the state its issues are moved
+	// to when their card is moved into the column
+	Targets map[string]string
+}
+
+const (
+	// minColumnWidth is the narrowest a column gets; when the terminal is
+	// too narrow for every column, the board scrolls sideways
+	minColumnWidth = 26
+
+	// headerHeight is the number of lines of the column headers
+	headerHeight = 2
+
+	// cardHeight is the number of lines of a card, including its border
+	cardHeight = 5
+
+	// gap is the space between columns
+	gap = 1
+
+	ellipsis = "…"
+)
+
+type Styles struct {
+	Header       lipgloss.Style
+	Count        lipgloss.Style
+	Scroll       lipgloss.Style
+	Card         lipgloss.Style
+	SelectedCard lipgloss.Style
+	Identifier   lipgloss.Style
+	Title        lipgloss.Style
+	Meta         lipgloss.Style
+	EmptyState   lipgloss.Style
+	Error        lipgloss.Style
+}
+
+// Model is a kanban board of issues with a column per workflow state.
+// Cards are moved between columns with H and L; the move is shown straight
+// away and sent as a CardMovedMsg to be saved.
+type Model struct {
+	columns []Column
+	issues  []domain.Issue
+
+	// cards holds the issues of each column, in the order of issues
+	cards [][]domain.Issue
+
+	// column is the selected column, and rows the selected card in each
+	// column
+	column int
+	rows   []int
+
+	// first is the first column shown, and offsets the first card shown
+	// in each column
+	first   int
+	offsets []int
+
+	err     error
+	focused bool
+	width   int
+	height  int
+	styles  Styles
+}
+
+func New() Model {
+	return Model{
+		width:  80,
+		height: 20,
+		styles: defaultStyles(),
+	}
+}
+
+func defaultStyles() Styles {
+	return Styles{
+		Header: lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#FAFAFA")).
+			Bold(true),
+		Count: lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#626262")),
+		Scroll: lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#874BFD")).
+			Bold(true),
+		Card: lipgloss.NewStyle().
+			BorderStyle(lipgloss.RoundedBorder()).
+			BorderForeground(lipgloss.Color("#3C3C3C")).
+			Padding(0, 1),
+		SelectedCard: lipgloss.NewStyle().
+			BorderStyle(lipgloss.RoundedBorder()).
+			BorderForeground(lipgloss.Color("#874BFD")).
+			Padding(0, 1),
+		Identifier: lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#874BFD")).
+			Bold(true),
+		Title: lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#FAFAFA")),
+		Meta: lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#626262")),
+		EmptyState: lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#626262")).
+			Italic(true),
+		Error: lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#FF5F87")),
+	}
+}
+
+func (m Model) Init() tea.Cmd {
+	return nil
+}
+
+func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
+	if !m.focused {
+		return m, nil
+	}
+
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok || len(m.columns) == 0 {
+		return m, nil
+	}
+
+	switch keyMsg.String() {
+	case "h", "left":
+		m.selectColumn(m.column - 1)
+	case "l", "right":
+		m.selectColumn(m.column + 1)
+	case "k", "up":
+		m.selectRow(m.rows[m.column] - 1)
+	case "j", "down":
+		m.selectRow(m.rows[m.column] + 1)
+	case "g", "home":
+		m.selectRow(0)
+	case "G", "end":
+		m.selectRow(len(m.cards[m.column]) - 1)
+	case "H", "shift+left":
+		return m, m.moveCard(-1)
+	case "L", "shift+right":
+		return m, m.moveCard(1)
+	case "enter":
+		if issue, ok := m.SelectedIssue(); ok {
+			return m, func() tea.Msg {
+				return messages.ItemSelectedMsg{Item: issue}
+			}
+		}
+	}
+	return m, nil
+}
+
+// moveCard moves the selected card to the column delta columns away
+func (m *Model) moveCard(delta int) tea.Cmd {
+	issue, ok := m.SelectedIssue()
+	target := m.column + delta
+	if !ok || target < 0 || target >= len(m.columns) {
+		return nil
+	}
+
+	column := m.columns[target]
+	stateID, ok := column.Targets[issue.TeamID]
+	if !ok {
+		return nil
+	}
+	moved := issue
+	moved.StateID = stateID
+	moved.Status = column.Name
+	moved.StateType = column.Type
+	m.UpdateIssue(moved)
+
+	return func() tea.Msg {
+		return messages.CardMovedMsg{Issue: issue, StateID: stateID}
+	}
+}
+
+// SetColumns sets the columns of the board, in the order they are shown
+func (m *Model) SetColumns(columns []Column) {
+	m.columns = columns
+	m.err = nil
+	m.arrange()
+}
+
+// SetError shows that the columns could not be loaded
+func (m *Model) SetError(err error) {
+	m.err = err
+}
+
+// SetIssues sets the issues on the board. Issues in a state without a
+// column are left out.
+func (m *Model) SetIssues(issues []domain.Issue) {
+	m.issues = append([]domain.Issue{}, issues...)
+	m.arrange()
+}
+
+// UpdateIssue replaces an issue on the board, moving its card if its
+// state changed. A selected card stays selected.
+func (m *Model) UpdateIssue(issue domain.Issue) {
+	for i := range m.issues {
+		if m.issues[i].LinearID == issue.LinearID {
+			m.issues[i] = issue
+			m.arrange()
+			return
+		}
+	}
+}
+
+// SelectedIssue returns the issue of the selected card
+func (m Model) SelectedIssue() (domain.Issue, bool) {
+	if m.column >= len(m.cards) {
+		return domain.Issue{}, false
+	}
+	cards := m.cards[m.column]
+	row := m.rows[m.column]
+	if row < 0 || row >= len(cards) {
+		return domain.Issue{}, false
+	}
+	return cards[row], true
+}
+
+// arrange sorts the issues into columns, keeping the selected card
+// selected
+func (m *Model) arrange() {
+	selected, hasSelected := m.SelectedIssue()
+
+	index := make(map[string]int, len(m.columns))
+	for i, column := range m.columns {
+		for _, stateID := range column.StateIDs {
+			index[stateID] = i
+		}
+	}
+
+	m.cards = make([][]domain.Issue, len(m.columns))
+	for _, issue := range m.issues {
+		if i, ok := index[issue.StateID]; ok {
+			m.cards[i] = append(m.cards[i], issue)
+		}
+	}
+
+	m.rows = resize(m.rows, len(m.columns))
+	m.offsets = resize(m.offsets, len(m.columns))
+	m.column = max(min(m.column, len(m.columns)-1), 0)
+
+	if hasSelected {
+		for i, cards := range m.cards {
+			for j, issue := range cards {
+				if issue.LinearID == selected.LinearID {
+					m.column, m.rows[i] = i, j
+				}
+			}
+		}
+	}
+
+	for i := range m.rows {
+		m.rows[i] = max(min(m.rows[i], len(m.cards[i])-1), 0)
+	}
+	m.scroll()
+}
+
+func resize(values []int, n int) []int {
+	if len(values) >= n {
+		return values[:n]
+	}
+	return append(values, make([]int, n-len(values))...)
+}
+
+func (m *Model) selectColumn(column int) {
+	m.column = max(min(column, len(m.columns)-1), 0)
+	m.scroll()
+}
+
+func (m *Model) selectRow(row int) {
+	m.rows[m.column] = max(min(row, len(m.cards[m.column])-1), 0)
+	m.scroll()
+}
+
+// scroll brings the selected column and the selected card of each column
+// into view
+func (m *Model) scroll() {
+	visible := m.visibleColumns()
+	if m.column < m.first {
+		m.first = m.column
+	} else if m.column >= m.first+visible {
+		m.first = m.column - visible + 1
+	}
+	m.first = max(min(m.first, len(m.columns)-visible), 0)
+
+	shown := m.visibleCards()
+	for i := range m.offsets {
+		row := m.rows[i]
+		if row < m.offsets[i] {
+			m.offsets[i] = row
+		} else if row >= m.offsets[i]+shown {
+			m.offsets[i] = row - shown + 1
+		}
+		m.offsets[i] = max(min(m.offsets[i], len(m.cards[i])-shown), 0)
+	}
+}
+
+// visibleColumns returns how many columns fit across the board
+func (m Model) visibleColumns() int {
+	return max(min(len(m.columns), m.width/minColumnWidth), 1)
+}
+
+// visibleCards returns how many cards fit in a column, leaving a line for
+// the count of cards below
+func (m Model) visibleCards() int {
+	return max((m.height-headerHeight-1)/cardHeight, 1)
+}
+
+func (m Model) View() string {
+	switch {
+	case m.err != nil:
+		return m.styles.Error.Width(m.width).Render("Could not load the board: " + m.err.Error())
+	case len(m.columns) == 0:
+		return m.styles.EmptyState.Render("Loading board...")
+	}
+
+	visible := m.visibleColumns()
+	columnWidth := m.width / visible
+	end := min(m.first+visible, len(m.columns))
+
+	rendered := make([]string, 0, end-m.first)
+	for i := m.first; i < end; i++ {
+		rendered = append(rendered, m.renderColumn(i, columnWidth))
+	}
+
+	board := lipgloss.JoinHorizontal(lipgloss.Top, rendered...)
+	return lipgloss.NewStyle().MaxWidth(m.width).MaxHeight(m.height).Render(board)
+}
+
+func (m Model) renderColumn(index, width int) string {
+	column := m.columns[index]
+	cards := m.cards[index]
+	innerWidth := max(width-gap, 1)
+
+	title := column.Name
+	if column.Color != "" {
+		title = lipgloss.NewStyle().Foreground(lipgloss.Color(column.Color)).Render("● ") + m.styles.Header.Render(title)
+	} else {
+		title = m.styles.Header.Render(title)
+	}
+	header := title + m.styles.Count.Render(fmt.Sprintf(" %d", len(cards)))
+
+	// Arrows show there are columns beyond the edges of the board
+	if index == m.first && m.first > 0 {
+		header = m.styles.Scroll.Render("‹ ") + header
+	}
+	if index == m.first+m.visibleColumns()-1 && index < len(m.columns)-1 {
+		header += m.styles.Scroll.Render(" ›")
+	}
+
+	lines := []string{
+		lipgloss.NewStyle().MaxWidth(innerWidth).Render(header),
+		m.styles.Count.Render(strings.Repeat("─", innerWidth)),
+	}
+
+	if len(cards) == 0 {
+		lines = append(lines, m.styles.EmptyState.Render("No issues"))
+	}
+
+	offset := m.offsets[index]
+	end := min(offset+m.visibleCards(), len(cards))
+	for i := offset; i < end; i++ {
+		selected := index == m.column && i == m.rows[index]
+		lines = append(lines, m.renderCard(cards[i], selected, innerWidth))
+	}
+	if below := len(cards) - end; below > 0 {
+		lines = append(lines, m.styles.Count.Render(fmt.Sprintf("↓ %d more", below)))
+	}
+
+	return lipgloss.NewStyle().Width(width).PaddingRight(gap).Render(lipgloss.JoinVertical(lipgloss.Left, lines...))
+}
+
+func (m Model) renderCard(issue domain.Issue, selected bool, width int) string {
+	style := m.styles.Card
+	if selected && m.focused {
+		style = m.styles.SelectedCard
+	}
+	textWidth := max(width-style.GetHorizontalFrameSize(), 1)
+
+	heading := issue.ID
+	if issue.Priority != "" && issue.Priority != "None" {
+		heading += " · " + issue.Priority
+	}
+
+	content := lipgloss.JoinVertical(lipgloss.Left,
+		m.styles.Identifier.Render(runewidth.Truncate(heading, textWidth, ellipsis)),
+		m.styles.Title.Render(runewidth.Truncate(issue.Title, textWidth, ellipsis)),
+		m.styles.Meta.Render(runewidth.Truncate(issue.Assignee, textWidth, ellipsis)),
+	)
+	return style.Width(max(width-style.GetHorizontalBorderSize(), 1)).Render(content)
+}
+
+func (m *Model) Focus() {
+	m.focused = true
+}
+
+func (m *Model) Blur() {
+	m.focused = false
+}
+
+func (m *Model) SetSize(width, height int) {
+	m.width = width
+	m.height = height
+	m.scroll()
+}
@@ -0,0 +1,213 @@
+package composer
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textarea"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/linear-tui/linear-tui/internal/ui/editor"
+	"github.com/linear-tui/linear-tui/internal/ui/messages"
+)
+
+// editorID identifies the composer's text in EditorFinishedMsg
+const editorID = "comment"
+
+// inputHeight is the number of lines of the textarea
+const inputHeight = 6
+
+type Styles struct {
+	Title lipgloss.Style
+	Box   lipgloss.Style
+	Hint  lipgloss.Style
+	Error lipgloss.Style
+}
+
+// Model writes a comment on an issue, either in a textarea or in the
+// user's editor
+type Model struct {
+	input   textarea.Model
+	issueID string
+
+	// initial is the text the comment started from; submitting it
+	// unchanged cancels
+	initial string
+
+	active     bool
+	inEditor   bool
+	submitting bool
+	err        error
+	width      int
+	styles     Styles
+}
+
+func New() Model {
+	input := textarea.New()
+	input.Placeholder = "Write a comment in markdown..."
+	input.ShowLineNumbers = false
+	input.CharLimit = 0
+	input.SetHeight(inputHeight)
+
+	return Model{
+		input:  input,
+		styles: defaultStyles(),
+	}
+}
+
+func defaultStyles() Styles {
+	return Styles{
+		Title: lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#FAFAFA")).
+			Bold(true),
+		Box: lipgloss.NewStyle().
+			BorderStyle(lipgloss.RoundedBorder()).
+			BorderForeground(lipgloss.Color("#874BFD")),
+		Hint: lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#626262")).
+			Italic(true),
+		Error: lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#FF5F87")),
+	}
+}
+
+func (m Model) Init() tea.Cmd {
+	return nil
+}
+
+// Open starts a comment on an issue. With external set the comment is
+// written in the user's editor, otherwise in the textarea.
+func (m *Model) Open(issueID string, external bool) tea.Cmd {
+	m.issueID = issueID
+	m.initial = ""
+	m.active = true
+	m.submitting = false
+	m.err = nil
+	m.input.Reset()
+
+	if external {
+		m.inEditor = true
+		return editor.Open(editorID, m.initial)
+	}
+	m.inEditor = false
+	return m.input.Focus()
+}
+
+func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
+	if !m.active {
+		return m, nil
+	}
+
+	switch msg := msg.(type) {
+	case messages.EditorFinishedMsg:
+		if msg.ID != editorID || !m.inEditor {
+			return m, nil
+		}
+		m.inEditor = false
+		if msg.Err != nil {
+			// Fall back to the textarea so the comment can still be written
+			m.err = msg.Err
+			return m, m.input.Focus()
+		}
+		return m.submit(msg.Text)
+
+	case messages.CommentCreatedMsg:
+		if msg.IssueID != m.issueID || !m.submitting {
+			return m, nil
+		}
+		m.submitting = false
+		if msg.Err != nil {
+			// Keep the text so it can be retried
+			m.err = msg.Err
+			return m, m.input.Focus()
+		}
+		m.close()
+		return m, nil
+
+	case tea.KeyMsg:
+		if m.submitting || m.inEditor {
+			return m, nil
+		}
+		switch msg.String() {
+		case "esc":
+			m.close()
+			return m, nil
+		case "ctrl+s":
+			return m.submit(m.input.Value())
+		case "ctrl+e":
+			m.inEditor = true
+			return m, editor.Open(editorID, m.input.Value())
+		}
+	}
+
+	var cmd tea.Cmd
+	m.input, cmd = m.input.Update(msg)
+	return m, cmd
+}
+
+// submit posts the comment, or cancels if it is empty or unchanged
+func (m Model) submit(text string) (Model, tea.Cmd) {
+	body := strings.TrimSpace(text)
+	if body == "" || body == strings.TrimSpace(m.initial) {
+		m.close()
+		return m, nil
+	}
+
+	m.input.SetValue(body)
+	m.input.Blur()
+	m.submitting = true
+	m.err = nil
+	issueID := m.issueID
+	return m, func() tea.Msg {
+		return messages.CommentSubmittedMsg{IssueID: issueID, Body: body}
+	}
+}
+
+func (m *Model) close() {
+	m.active = false
+	m.inEditor = false
+	m.submitting = false
+	m.input.Reset()
+	m.input.Blur()
+}
+
+func (m Model) View() string {
+	if !m.active {
+		return ""
+	}
+
+	var status string
+	switch {
+	case m.submitting:
+		status = m.styles.Hint.Render("Posting comment...")
+	case m.inEditor:
+		status = m.styles.Hint.Render("Editing in external editor...")
+	case m.err != nil:
+		status = m.styles.Error.Width(m.width).Render(m.err.Error())
+	default:
+		status = m.styles.Hint.Render("ctrl+s: post | ctrl+e: open in $EDITOR | esc: cancel")
+	}
+
+	return lipgloss.JoinVertical(lipgloss.Left,
+		m.styles.Title.Render("New comment"),
+		m.styles.Box.Render(m.input.View()),
+		status,
+	)
+}
+
+// Active reports whether a comment is being written
+func (m Model) Active() bool {
+	return m.active
+}
+
+// Height returns the number of lines the composer takes
+func (m Model) Height() int {
+	if !m.active {
+		return 0
+	}
+	return lipgloss.Height(m.View())
+}
+
+func (m *Model) SetWidth(width int) {
+	m.width = width
+	m.input.SetWidth(max(width-m.styles.Box.GetHorizontalFrameSize(), 1))
+}
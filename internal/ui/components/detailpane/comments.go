@@ -0,0 +1,108 @@
+package detailpane
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/linear-tui/linear-tui/internal/domain"
+)
+
+// maxReplyDepth limits how far replies are indented, so that deep threads
+// stay readable in a narrow pane
+const maxReplyDepth = 4
+
+// renderComments renders the activity of the issue shown: its comment
+// threads interleaved with the changes made to it. Either can fail to load
+// without hiding the other.
+func (m *Model) renderComments() string {
+	title := "Activity"
+	if !m.commentsLoading && m.commentsErr == nil {
+		title = fmt.Sprintf("Activity · %s", plural(len(m.comments), "comment"))
+	}
+	lines := []string{m.styles.Section.Render(title), m.renderRule()}
+
+	if m.commentsErr != nil {
+		lines = append(lines, m.styles.Error.Width(m.contentWidth()).Render("Could not load comments: "+m.commentsErr.Error()))
+	}
+	if m.historyErr != nil {
+		lines = append(lines, m.styles.Error.Width(m.contentWidth()).Render("Could not load history: "+m.historyErr.Error()))
+	}
+
+	switch {
+	case m.commentsLoading || m.historyLoading:
+		lines = append(lines, m.styles.EmptyState.Render("Loading activity..."))
+	case len(m.comments) == 0 && len(m.history) == 0:
+		if m.commentsErr == nil && m.historyErr == nil {
+			lines = append(lines, m.styles.EmptyState.Render("No activity yet"))
+		}
+	default:
+		lines = append(lines, m.renderTimeline(commentThread(m.comments))...)
+	}
+
+	return lipgloss.JoinVertical(lipgloss.Left, lines...)
+}
+
+// commentThread arranges comments into a thread: the top-level comments and
+// the replies to each comment, all oldest first. Replies to comments that
+// are not loaded are shown at the top level.
+func commentThread(comments []domain.Comment) ([]domain.Comment, map[string][]domain.Comment) {
+	sorted := append([]domain.Comment{}, comments...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].CreatedAt.Before(sorted[j].CreatedAt)
+	})
+
+	loaded := make(map[string]bool, len(sorted))
+	for _, comment := range sorted {
+		loaded[comment.ID] = true
+	}
+
+	var roots []domain.Comment
+	replies := make(map[string][]domain.Comment)
+	for _, comment := range sorted {
+		if comment.ParentID != "" && loaded[comment.ParentID] {
+			replies[comment.ParentID] = append(replies[comment.ParentID], comment)
+		} else {
+			roots = append(roots, comment)
+		}
+	}
+	return roots, replies
+}
+
+// renderCommentTree renders a comment followed by its replies, which are
+// indented under it
+func (m *Model) renderCommentTree(comment domain.Comment, replies map[string][]domain.Comment, depth, width int) string {
+	header := m.styles.Author.Render(comment.Author) + m.styles.Meta.Render(" · "+relativeTime(comment.CreatedAt))
+	if comment.UpdatedAt.Sub(comment.CreatedAt) > time.Minute {
+		header += m.styles.Meta.Render(" (edited)")
+	}
+	if comment.Queued {
+		header += m.styles.Meta.Render(" (queued)")
+	}
+
+	parts := []string{"", header, m.renderMarkdown(comment.Body, width)}
+
+	children := replies[comment.ID]
+	if len(children) > 0 {
+		childDepth, childWidth := depth, width
+		indent := 0
+		if depth < maxReplyDepth {
+			childDepth = depth + 1
+			indent = m.styles.Reply.GetHorizontalFrameSize()
+			childWidth = max(width-indent, 1)
+		}
+
+		var rendered []string
+		for _, child := range children {
+			rendered = append(rendered, m.renderCommentTree(child, replies, childDepth, childWidth))
+		}
+		thread := lipgloss.JoinVertical(lipgloss.Left, rendered...)
+		if indent > 0 {
+			thread = m.styles.Reply.Render(thread)
+		}
+		parts = append(parts, thread)
+	}
+
+	return lipgloss.JoinVertical(lipgloss.Left, parts...)
+}
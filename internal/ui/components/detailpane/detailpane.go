@@ -2,10 +2,15 @@ package detailpane
 
 import (
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/charmbracelet/bubbles/viewport"
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/glamour"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/linear-tui/linear-tui/internal/domain"
+	"github.com/linear-tui/linear-tui/internal/ui/components/composer"
 	"github.com/linear-tui/linear-tui/internal/ui/messages"
 )
 
@@ -13,8 +18,22 @@ type Styles struct {
 	Border     lipgloss.Style
 	EmptyState lipgloss.Style
 	Content    lipgloss.Style
+	Identifier lipgloss.Style
+	Title      lipgloss.Style
+	Label      lipgloss.Style
+	Value      lipgloss.Style
+	Rule       lipgloss.Style
+	Section    lipgloss.Style
+	Author     lipgloss.Style
+	Meta       lipgloss.Style
+	Reply      lipgloss.Style
+	Error      lipgloss.Style
+	Selected   lipgloss.Style
 }
 
+// labelWidth is the width of the field labels in the header block
+const labelWidth = 10
+
 type Model struct {
 	item     interface{}
 	focused  bool
@@ -22,6 +41,36 @@ type Model struct {
 	width    int
 	height   int
 	styles   Styles
+
+	// userNames maps @mention handles to user names
+	userNames map[string]string
+
+	// comments is the thread of the issue shown, once it has loaded
+	comments        []domain.Comment
+	commentsLoading bool
+	commentsErr     error
+
+	// history holds the changes made to the issue shown, once they have
+	// loaded
+	history        []domain.HistoryEvent
+	historyLoading bool
+	historyErr     error
+
+	// relations are the issues linked to the issue shown, once they have
+	// loaded, and link the index of the selected one among links, or -1
+	relations        *domain.IssueRelations
+	relationsLoading bool
+	relationsErr     error
+	link             int
+
+	// renderers holds a markdown renderer for each width in use; replies
+	// are indented, so they are rendered narrower than the description
+	renderers map[int]*glamour.TermRenderer
+
+	composer composer.Model
+
+	// externalEditor opens new comments in $EDITOR instead of the textarea
+	externalEditor bool
 }
 
 func (m Model) Init() tea.Cmd {
@@ -29,17 +78,77 @@ func (m Model) Init() tea.Cmd {
 }
 
 func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
+	// Comments and the results of writing one arrive regardless of focus
+	switch msg := msg.(type) {
+	case messages.CommentsLoadedMsg:
+		if issue, ok := m.item.(domain.Issue); ok && issue.LinearID == msg.IssueID {
+			m.comments = msg.Comments
+			m.commentsErr = msg.Err
+			m.commentsLoading = false
+			m.refresh()
+		}
+		return m, nil
+
+	case messages.HistoryLoadedMsg:
+		if issue, ok := m.item.(domain.Issue); ok && issue.LinearID == msg.IssueID {
+			m.history = msg.Events
+			m.historyErr = msg.Err
+			m.historyLoading = false
+			m.refresh()
+		}
+		return m, nil
+
+	case messages.RelationsLoadedMsg:
+		if issue, ok := m.item.(domain.Issue); ok && issue.LinearID == msg.IssueID {
+			m.relations = msg.Relations
+			m.relationsErr = msg.Err
+			m.relationsLoading = false
+			if m.link >= len(m.links()) {
+				m.link = -1
+			}
+			m.refresh()
+		}
+		return m, nil
+
+	case messages.EditorFinishedMsg, messages.CommentCreatedMsg:
+		return m.updateComposer(msg)
+	}
+
 	if !m.focused {
 		return m, nil
 	}
 
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
+		// The composer takes all keys while a comment is being written
+		if m.composer.Active() {
+			return m.updateComposer(msg)
+		}
+
 		switch msg.String() {
 		case "q", "esc":
 			return m, func() tea.Msg {
 				return messages.CloseDetailPaneMsg{}
 			}
+		case "c":
+			if issue, ok := m.item.(domain.Issue); ok {
+				cmd := m.composer.Open(issue.LinearID, m.externalEditor)
+				m.layout()
+				return m, cmd
+			}
+		case "]":
+			m.selectLink(1)
+			return m, nil
+		case "[":
+			m.selectLink(-1)
+			return m, nil
+		case "enter":
+			if links := m.links(); m.link >= 0 && m.link < len(links) {
+				issueID := links[m.link].issue.LinearID
+				return m, func() tea.Msg {
+					return messages.OpenIssueMsg{IssueID: issueID}
+				}
+			}
 		}
 	}
 
@@ -48,32 +157,94 @@ func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 	return m, cmd
 }
 
+// updateComposer passes a message to the composer and makes room for it
+// as it opens and closes
+func (m Model) updateComposer(msg tea.Msg) (Model, tea.Cmd) {
+	var cmd tea.Cmd
+	m.composer, cmd = m.composer.Update(msg)
+	m.layout()
+	return m, cmd
+}
+
 func (m Model) View() string {
 	if m.item == nil {
 		return m.renderEmptyState()
 	}
 
-	content := m.renderItemDetails()
-	m.viewport.SetContent(content)
+	content := m.viewport.View()
+	if m.composer.Active() {
+		content = lipgloss.JoinVertical(lipgloss.Left, content, m.composer.View())
+	}
 
 	return lipgloss.NewStyle().
 		Width(m.width).
 		Height(m.height).
 		BorderStyle(lipgloss.NormalBorder()).
 		BorderLeft(true).
-		Render(m.viewport.View())
+		Render(content)
 }
 
+// SetItem shows an item. For issues the comment thread, the history and
+// the linked issues are expected to follow in a CommentsLoadedMsg, a
+// HistoryLoadedMsg and a RelationsLoadedMsg.
 func (m *Model) SetItem(item interface{}) {
 	m.item = item
+	m.comments = nil
+	m.commentsErr = nil
+	_, m.commentsLoading = item.(domain.Issue)
+	m.history = nil
+	m.historyErr = nil
+	m.historyLoading = m.commentsLoading
+	m.relations = nil
+	m.relationsErr = nil
+	m.relationsLoading = m.commentsLoading
+	m.link = -1
+	m.refresh()
 	m.viewport.GotoTop()
 }
 
+// UpdateIssue shows a newer version of the issue shown, keeping its
+// comments and scroll position. Other issues are ignored.
+func (m *Model) UpdateIssue(issue domain.Issue) {
+	current, ok := m.item.(domain.Issue)
+	if !ok || current.LinearID != issue.LinearID {
+		return
+	}
+	m.item = issue
+	m.refresh()
+}
+
+// Item returns the item shown, or nil if there is none
+func (m Model) Item() interface{} {
+	return m.item
+}
+
+// SetExternalEditor sets whether new comments are written in $EDITOR
+// rather than in the pane
+func (m *Model) SetExternalEditor(external bool) {
+	m.externalEditor = external
+}
+
+// InputActive reports whether the pane is taking text input, in which case
+// it should receive all keys
+func (m Model) InputActive() bool {
+	return m.composer.Active()
+}
+
+// SetUserNames sets the user names @mentions are resolved to, keyed by
+// lower case handle
+func (m *Model) SetUserNames(names map[string]string) {
+	m.userNames = names
+	m.refresh()
+}
+
 func New() Model {
 	vp := viewport.New(40, 20)
 	return Model{
-		viewport: vp,
-		styles:   defaultStyles(),
+		viewport:  vp,
+		styles:    defaultStyles(),
+		renderers: make(map[int]*glamour.TermRenderer),
+		composer:  composer.New(),
 	}
 }
 
@@ -88,6 +259,39 @@ func defaultStyles() Styles {
 			Italic(true),
 		Content: lipgloss.NewStyle().
 			Foreground(lipgloss.Color("#FAFAFA")),
+		Identifier: lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#874BFD")).
+			Bold(true),
+		Title: lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#FAFAFA")).
+			Bold(true),
+		Label: lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#626262")).
+			Width(labelWidth),
+		Value: lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#FAFAFA")),
+		Rule: lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#626262")),
+		Section: lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#FAFAFA")).
+			Bold(true).
+			MarginTop(1),
+		Author: lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#FAFAFA")).
+			Bold(true),
+		Meta: lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#626262")),
+		Reply: lipgloss.NewStyle().
+			BorderStyle(lipgloss.NormalBorder()).
+			BorderLeft(true).
+			BorderForeground(lipgloss.Color("#3C3C3C")).
+			PaddingLeft(1),
+		Error: lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#FF5F87")),
+		Selected: lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#FAFAFA")).
+			Background(lipgloss.Color("#874BFD")).
+			Bold(true),
 	}
 }
 
@@ -95,19 +299,158 @@ func (m Model) renderEmptyState() string {
 	return m.styles.EmptyState.Render("Select an item to view details")
 }
 
-func (m Model) renderItemDetails() string {
+// refresh renders the item into the viewport. Rendering markdown is too
+// slow to repeat on every frame, so it happens when the item or size
+// changes.
+func (m *Model) refresh() {
 	if m.item == nil {
-		return m.renderEmptyState()
+		m.viewport.SetContent("")
+		return
+	}
+	m.viewport.SetContent(m.renderItemDetails())
+}
+
+func (m *Model) renderItemDetails() string {
+	switch item := m.item.(type) {
+	case domain.Issue:
+		return m.renderIssue(item)
+	case domain.Project:
+		return m.renderProject(item)
 	}
-	// Stub implementation - format the item as string
 	return m.styles.Content.Render(fmt.Sprintf("%v", m.item))
 }
 
+func (m *Model) renderIssue(issue domain.Issue) string {
+	header := lipgloss.JoinVertical(lipgloss.Left,
+		m.styles.Identifier.Render(issue.ID),
+		m.styles.Title.Width(m.contentWidth()).Render(issue.Title),
+		"",
+		m.renderField("Status", issue.Status),
+		m.renderField("Priority", issue.Priority),
+		m.renderField("Assignee", issue.Assignee),
+		m.renderField("Team", issue.Team),
+		m.renderField("Project", issue.Project),
+		m.renderField("Labels", m.renderLabels(issue.Labels)),
+		m.renderField("Cycle", issue.Cycle),
+		m.renderField("Created", formatTimestamp(issue.CreatedAt)),
+		m.renderField("Updated", formatTimestamp(issue.UpdatedAt)),
+	)
+
+	parts := []string{header, m.renderRule()}
+	if relations := m.renderRelations(); relations != "" {
+		parts = append(parts, relations)
+	}
+	parts = append(parts, m.renderDescription(issue.Description), m.renderComments())
+	return lipgloss.JoinVertical(lipgloss.Left, parts...)
+}
+
+func (m *Model) renderProject(project domain.Project) string {
+	target := ""
+	if !project.TargetDate.IsZero() {
+		target = project.TargetDate.Format("Jan 02 2006")
+	}
+
+	header := lipgloss.JoinVertical(lipgloss.Left,
+		m.styles.Title.Width(m.contentWidth()).Render(project.Name),
+		"",
+		m.renderField("Status", project.Status),
+		m.renderField("Progress", fmt.Sprintf("%.0f%%", project.Progress*100)),
+		m.renderField("Target", target),
+		m.renderField("Created", formatTimestamp(project.CreatedAt)),
+	)
+
+	return lipgloss.JoinVertical(lipgloss.Left, header, m.renderRule(), m.renderDescription(project.Description))
+}
+
+// renderField renders a line of the header block, with a dash for empty
+// values
+func (m Model) renderField(label, value string) string {
+	if value == "" {
+		value = "-"
+	}
+	valueWidth := max(m.contentWidth()-labelWidth, 1)
+	return lipgloss.JoinHorizontal(lipgloss.Top,
+		m.styles.Label.Render(label),
+		m.styles.Value.Width(valueWidth).Render(value),
+	)
+}
+
+// renderLabels renders labels as chips, each a dot in the label's color
+// followed by its name
+func (m Model) renderLabels(labels []domain.Label) string {
+	chips := make([]string, len(labels))
+	for i, label := range labels {
+		dot := lipgloss.NewStyle().Foreground(lipgloss.Color(label.Color)).Render("●")
+		chips[i] = dot + m.styles.Value.Render(" "+label.Name)
+	}
+	return strings.Join(chips, m.styles.Value.Render("  "))
+}
+
+func (m Model) renderRule() string {
+	return m.styles.Rule.Render(strings.Repeat("─", m.contentWidth()))
+}
+
+func (m *Model) renderDescription(description string) string {
+	if strings.TrimSpace(description) == "" {
+		return m.styles.EmptyState.Render("No description")
+	}
+	return m.renderMarkdown(description, m.contentWidth())
+}
+
+// formatTimestamp formats a time along with how long ago it was
+func formatTimestamp(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Local().Format("Jan 02 2006 15:04") + " · " + relativeTime(t)
+}
+
+// relativeTime describes how long ago t was, such as "3 days ago"
+func relativeTime(t time.Time) string {
+	d := time.Since(t)
+	switch {
+	case d < time.Minute:
+		return "just now"
+	case d < time.Hour:
+		return plural(int(d.Minutes()), "minute") + " ago"
+	case d < 24*time.Hour:
+		return plural(int(d.Hours()), "hour") + " ago"
+	case d < 30*24*time.Hour:
+		return plural(int(d.Hours()/24), "day") + " ago"
+	case d < 365*24*time.Hour:
+		return plural(int(d.Hours()/(24*30)), "month") + " ago"
+	default:
+		return plural(int(d.Hours()/(24*365)), "year") + " ago"
+	}
+}
+
+func plural(n int, unit string) string {
+	if n == 1 {
+		return "1 " + unit
+	}
+	return fmt.Sprintf("%d %ss", n, unit)
+}
+
+// contentWidth is the width available for content inside the border
+func (m Model) contentWidth() int {
+	return max(m.viewport.Width, 1)
+}
+
 func (m *Model) SetSize(width, height int) {
+	if width != m.width {
+		clear(m.renderers)
+	}
 	m.width = width
 	m.height = height
-	m.viewport.Width = width - 2 // Account for border
-	m.viewport.Height = height - 2
+	m.layout()
+	m.refresh()
+}
+
+// layout sizes the viewport and the composer beneath it
+func (m *Model) layout() {
+	m.viewport.Width = m.width - 2 // Account for border
+	m.composer.SetWidth(m.viewport.Width)
+	m.viewport.Height = max(m.height-2-m.composer.Height(), 1)
 }
 
 func (m *Model) Focus() {
@@ -0,0 +1,88 @@
+package detailpane
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/linear-tui/linear-tui/internal/domain"
+)
+
+// describeEvent describes a change to an issue, following the name of who
+// made it
+func describeEvent(event domain.HistoryEvent) string {
+	from, to := event.From, event.To
+	switch event.Field {
+	case domain.HistoryStatus:
+		if from == "" {
+			return "set the status to " + to
+		}
+		return "moved the issue from " + from + " to " + to
+	case domain.HistoryAssignee:
+		switch {
+		case from == "":
+			return "assigned the issue to " + to
+		case to == "":
+			return "unassigned " + from
+		}
+		return "reassigned the issue from " + from + " to " + to
+	case domain.HistoryPriority:
+		switch {
+		case from == "None":
+			return "set the priority to " + to
+		case to == "None":
+			return "removed the priority"
+		}
+		return "changed the priority from " + from + " to " + to
+	case domain.HistoryLabels:
+		switch {
+		case from == "":
+			return "added " + labels(to)
+		case to == "":
+			return "removed " + labels(from)
+		}
+		return "added " + labels(to) + " and removed " + labels(from)
+	}
+	return "changed the " + event.Field
+}
+
+// renderEvent renders a line of the timeline for a change to the issue
+func (m *Model) renderEvent(event domain.HistoryEvent) string {
+	text := m.styles.Author.Render(event.Actor) +
+		m.styles.Meta.Render(" "+describeEvent(event)+" · "+relativeTime(event.CreatedAt))
+	return lipgloss.NewStyle().Width(m.contentWidth()).Render(text)
+}
+
+// renderTimeline renders the comment threads interleaved with the changes
+// to the issue, oldest first. Changes made in a row are kept together,
+// while each comment thread is set apart by a blank line.
+func (m *Model) renderTimeline(roots []domain.Comment, replies map[string][]domain.Comment) []string {
+	events := append([]domain.HistoryEvent{}, m.history...)
+	sort.SliceStable(events, func(i, j int) bool {
+		return events[i].CreatedAt.Before(events[j].CreatedAt)
+	})
+
+	var lines []string
+	afterEvent := false
+	for len(roots) > 0 || len(events) > 0 {
+		if len(events) > 0 && (len(roots) == 0 || events[0].CreatedAt.Before(roots[0].CreatedAt)) {
+			if !afterEvent {
+				lines = append(lines, "")
+			}
+			lines = append(lines, m.renderEvent(events[0]))
+			events, afterEvent = events[1:], true
+			continue
+		}
+		lines = append(lines, m.renderCommentTree(roots[0], replies, 0, m.contentWidth()))
+		roots, afterEvent = roots[1:], false
+	}
+	return lines
+}
+
+// labels names a comma separated list of labels
+func labels(names string) string {
+	if strings.Contains(names, ",") {
+		return "labels " + names
+	}
+	return "label " + names
+}
@@ -0,0 +1,104 @@
+package detailpane
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/charmbracelet/glamour"
+	"github.com/charmbracelet/glamour/styles"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// newRenderer creates a markdown renderer wrapping at width. The dark
+// style is used as is, apart from the document margins, so that rendered
+// markdown lines up with the header block.
+func newRenderer(width int) (*glamour.TermRenderer, error) {
+	style := styles.DarkStyleConfig
+	noMargin := uint(0)
+	style.Document.Margin = &noMargin
+	style.Document.BlockPrefix = ""
+	style.Document.BlockSuffix = ""
+
+	return glamour.NewTermRenderer(
+		glamour.WithStyles(style),
+		glamour.WithWordWrap(width),
+		glamour.WithEmoji(),
+	)
+}
+
+// renderMarkdown renders markdown wrapped at width, falling back to wrapped
+// plain text if it cannot be rendered
+func (m *Model) renderMarkdown(markdown string, width int) string {
+	markdown = resolveMentions(markdown, m.userNames)
+
+	renderer, ok := m.renderers[width]
+	if !ok {
+		var err error
+		if renderer, err = newRenderer(width); err != nil {
+			return lipgloss.NewStyle().Width(width).Render(markdown)
+		}
+		m.renderers[width] = renderer
+	}
+
+	rendered, err := renderer.Render(markdown)
+	if err != nil {
+		return lipgloss.NewStyle().Width(width).Render(markdown)
+	}
+	return strings.Trim(rendered, "\n")
+}
+
+// mentionPattern matches @handles not preceded by characters that would
+// make them part of an email address or a URL
+var mentionPattern = regexp.MustCompile(`(^|[^\w.@/])@([A-Za-z0-9][\w.-]*)`)
+
+// resolveMentions replaces @handles with the names of the users they
+// refer to. Handles are looked up in lower case; text in code blocks and
+// code spans is left alone.
+func resolveMentions(markdown string, names map[string]string) string {
+	if len(names) == 0 {
+		return markdown
+	}
+
+	lines := strings.Split(markdown, "\n")
+	inFence := false
+	for i, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~") {
+			inFence = !inFence
+			continue
+		}
+		if inFence {
+			continue
+		}
+
+		// Even parts of a line split on backticks lie outside code spans
+		parts := strings.Split(line, "`")
+		for j := 0; j < len(parts); j += 2 {
+			parts[j] = replaceMentions(parts[j], names)
+		}
+		lines[i] = strings.Join(parts, "`")
+	}
+	return strings.Join(lines, "\n")
+}
+
+func replaceMentions(text string, names map[string]string) string {
+	var b strings.Builder
+	last := 0
+	for _, match := range mentionPattern.FindAllStringSubmatchIndex(text, -1) {
+		handleStart, handleEnd := match[4], match[5]
+		handle := text[handleStart:handleEnd]
+
+		// A handle at the end of a sentence picks up its full stop
+		trailing := len(handle) - len(strings.TrimRight(handle, "."))
+		name, ok := names[strings.ToLower(handle[:len(handle)-trailing])]
+		if !ok {
+			continue
+		}
+
+		b.WriteString(text[last : handleStart-1])
+		b.WriteString("**@" + name + "**")
+		last = handleEnd - trailing
+	}
+	b.WriteString(text[last:])
+	return b.String()
+}
@@ -0,0 +1,129 @@
+package detailpane
+
+import (
+	"fmt"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/linear-tui/linear-tui/internal/domain"
+	"github.com/mattn/go-runewidth"
+)
+
+// relationWidth is the width of the relation names before linked issues
+const relationWidth = 15
+
+// link is a linked issue shown in the pane, with how it is linked
+type link struct {
+	relation string
+	issue    domain.IssueLink
+}
+
+// links returns the issues linked to the issue shown, in the order they
+// are shown: the parent, the related issues and then the sub-issues
+func (m Model) links() []link {
+	if m.relations == nil {
+		return nil
+	}
+
+	var links []link
+	if m.relations.Parent != nil {
+		links = append(links, link{relation: "Parent", issue: *m.relations.Parent})
+	}
+	for _, relation := range m.relations.Relations {
+		links = append(links, link{relation: relation.Type, issue: relation.Issue})
+	}
+	for _, child := range m.relations.Children {
+		links = append(links, link{issue: child})
+	}
+	return links
+}
+
+// selectLink moves the selected link by delta, wrapping around
+func (m *Model) selectLink(delta int) {
+	count := len(m.links())
+	if count == 0 {
+		return
+	}
+	if m.link < 0 {
+		m.link = 0
+		if delta < 0 {
+			m.link = count - 1
+		}
+	} else {
+		m.link = (m.link + delta + count) % count
+	}
+	m.refresh()
+}
+
+// renderRelations renders the issues linked to the issue shown, with the
+// sub-issues and how many of them are done in a section of their own. It
+// renders nothing for issues without links.
+func (m *Model) renderRelations() string {
+	var lines []string
+	switch {
+	case m.relationsLoading:
+		return lipgloss.JoinVertical(lipgloss.Left, m.styles.EmptyState.Render("Loading links..."), m.renderRule())
+	case m.relationsErr != nil:
+		return lipgloss.JoinVertical(lipgloss.Left,
+			m.styles.Error.Width(m.contentWidth()).Render("Could not load links: "+m.relationsErr.Error()),
+			m.renderRule(),
+		)
+	}
+
+	links := m.links()
+	children := 0
+	for i, l := range links {
+		if l.relation == "" {
+			children = len(links) - i
+			break
+		}
+		lines = append(lines, m.renderLink(l, i == m.link))
+	}
+
+	if children > 0 {
+		done := 0
+		for _, child := range m.relations.Children {
+			if closed(child) {
+				done++
+			}
+		}
+		if len(lines) > 0 {
+			lines = append(lines, "")
+		}
+		lines = append(lines, m.styles.Section.UnsetMarginTop().Render(fmt.Sprintf("Sub-issues (%d/%d done)", done, children)))
+		for i := len(links) - children; i < len(links); i++ {
+			lines = append(lines, m.renderLink(links[i], i == m.link))
+		}
+	}
+
+	if len(lines) == 0 {
+		return ""
+	}
+	return lipgloss.JoinVertical(lipgloss.Left, append(lines, m.renderRule())...)
+}
+
+// renderLink renders a line of a linked issue, highlighted if it is the
+// selected link. Sub-issues show whether they are done in place of the
+// relation.
+func (m Model) renderLink(l link, selected bool) string {
+	prefix := m.styles.Label.Width(relationWidth).Render(l.relation)
+	if l.relation == "" {
+		mark := "○ "
+		if closed(l.issue) {
+			mark = "✓ "
+		}
+		prefix = m.styles.Meta.Render(mark)
+	}
+
+	width := max(m.contentWidth()-lipgloss.Width(prefix), 1)
+	text := runewidth.Truncate(fmt.Sprintf("%s %s · %s", l.issue.ID, l.issue.Title, l.issue.Status), width, "…")
+	style := m.styles.Value
+	if selected {
+		style = m.styles.Selected
+	}
+	return prefix + style.Render(text)
+}
+
+// closed reports whether a linked issue is done or canceled
+func closed(issue domain.IssueLink) bool {
+	return issue.StateType == "completed" || issue.StateType == "canceled"
+}
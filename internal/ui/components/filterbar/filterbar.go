@@ -0,0 +1,218 @@
+package filterbar
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/linear-tui/linear-tui/internal/query"
+	"github.com/linear-tui/linear-tui/internal/ui/messages"
+)
+
+// height is the number of lines the filter bar takes while it is shown:
+// the input and a line for hints or errors
+const height = 2
+
+type Styles struct {
+	Prompt  lipgloss.Style
+	Applied lipgloss.Style
+	Hint    lipgloss.Style
+	Error   lipgloss.Style
+}
+
+type Model struct {
+	input   textinput.Model
+	applied string
+	err     error
+	focused bool
+	width   int
+	styles  Styles
+}
+
+func New() Model {
+	styles := defaultStyles()
+
+	input := textinput.New()
+	input.Prompt = "/ "
+	input.PromptStyle = styles.Prompt
+	input.Placeholder = `assignee:me status:"In Progress" priority:>=high label:bug updated:<7d`
+
+	return Model{
+		input:  input,
+		styles: styles,
+	}
+}
+
+func defaultStyles() Styles {
+	return Styles{
+		Prompt: lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#874BFD")).
+			Bold(true),
+		Applied: lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#FAFAFA")),
+		Hint: lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#626262")).
+			Italic(true),
+		Error: lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#FF5F87")),
+	}
+}
+
+func (m Model) Init() tea.Cmd {
+	return nil
+}
+
+func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
+	if !m.focused {
+		return m, nil
+	}
+
+	if msg, ok := msg.(tea.KeyMsg); ok {
+		switch msg.String() {
+		case "enter":
+			// Spans in errors refer to the input as typed, so it is parsed
+			// before trimming
+			q, err := query.Parse(m.input.Value())
+			if err != nil {
+				m.err = err
+				return m, nil
+			}
+			m.err = nil
+			text := strings.TrimSpace(m.input.Value())
+			return m, func() tea.Msg {
+				return messages.FilterSubmittedMsg{Text: text, Query: q}
+			}
+		case "esc":
+			m.input.SetValue(m.applied)
+			m.err = nil
+			return m, tea.Batch(searchChanged(m.applied), func() tea.Msg {
+				return messages.FilterClosedMsg{}
+			})
+		}
+	}
+
+	previous := m.input.Value()
+	var cmd tea.Cmd
+	m.input, cmd = m.input.Update(msg)
+	if m.input.Value() == previous {
+		return m, cmd
+	}
+
+	// Validate as the user types so mistakes are pointed out immediately
+	_, m.err = query.Parse(m.input.Value())
+	if m.err != nil {
+		return m, cmd
+	}
+	return m, tea.Batch(cmd, searchChanged(m.input.Value()))
+}
+
+// searchChanged reports the free text of a filter so the list can be
+// searched as the user types
+func searchChanged(text string) tea.Cmd {
+	q, err := query.Parse(text)
+	if err != nil {
+		return nil
+	}
+	return func() tea.Msg {
+		return messages.SearchChangedMsg{Text: q.SearchText()}
+	}
+}
+
+func (m Model) View() string {
+	if !m.Active() {
+		return ""
+	}
+
+	var line string
+	if m.focused {
+		line = m.input.View()
+	} else {
+		line = m.input.PromptStyle.Render(m.input.Prompt) + m.styles.Applied.Render(m.applied)
+	}
+
+	return lipgloss.JoinVertical(lipgloss.Left,
+		lipgloss.NewStyle().MaxWidth(m.width).Render(line),
+		lipgloss.NewStyle().MaxWidth(m.width).Render(m.statusLine()),
+	)
+}
+
+// statusLine underlines the span of the current error, or shows a hint
+func (m Model) statusLine() string {
+	var queryErr *query.Error
+	if errors.As(m.err, &queryErr) {
+		text := m.input.Value()
+		start := min(queryErr.Span.Start, len(text))
+		end := min(max(queryErr.Span.End, start+1), len(text)+1)
+
+		offset := lipgloss.Width(m.input.Prompt) + lipgloss.Width(text[:start])
+		width := max(lipgloss.Width(text[start:min(end, len(text))]), 1)
+
+		return strings.Repeat(" ", offset) +
+			m.styles.Error.Render(strings.Repeat("^", width)+" "+queryErr.Message)
+	}
+	if m.err != nil {
+		return m.styles.Error.Render(m.err.Error())
+	}
+
+	if m.focused {
+		return m.styles.Hint.Render("enter: apply | esc: cancel")
+	}
+	return m.styles.Hint.Render("/: edit filter | esc: clear filter")
+}
+
+// Focus starts editing the filter
+func (m *Model) Focus() tea.Cmd {
+	m.focused = true
+	m.input.CursorEnd()
+	return m.input.Focus()
+}
+
+func (m *Model) Blur() {
+	m.focused = false
+	m.input.Blur()
+}
+
+// SetApplied records a submitted query as the applied filter
+func (m *Model) SetApplied(text string) {
+	m.applied = text
+	m.err = nil
+	m.input.SetValue(text)
+}
+
+// SetError shows an error for the submitted query, such as an unknown
+// user. Errors located in the query are underlined.
+func (m *Model) SetError(err error) {
+	m.err = err
+}
+
+// Clear removes the applied filter
+func (m *Model) Clear() {
+	m.applied = ""
+	m.err = nil
+	m.input.SetValue("")
+}
+
+// Applied returns the text of the applied filter
+func (m Model) Applied() string {
+	return m.applied
+}
+
+// Active reports whether the filter bar is shown
+func (m Model) Active() bool {
+	return m.focused || m.applied != ""
+}
+
+// Height returns the number of lines the filter bar takes
+func (m Model) Height() int {
+	if !m.Active() {
+		return 0
+	}
+	return height
+}
+
+func (m *Model) SetWidth(width int) {
+	m.width = width
+	m.input.Width = max(width-lipgloss.Width(m.input.Prompt)-1, 1)
+}
@@ -1,6 +1,10 @@
 package footer
 
 import (
+	"fmt"
+	"strings"
+	"time"
+
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 )
@@ -9,16 +13,59 @@ type Model struct {
 	width  int
 	height int
 	styles Styles
+
+	// message is an error shown in place of the key help until the next
+	// key press
+	message string
+
+	// toast is a notice shown in place of the key help for toastDuration.
+	// toastID tells its expiry from that of an earlier toast.
+	toast   string
+	toastID int
+
+	// online is whether Linear can be reached, and queued the number of
+	// edits waiting to be sent to it
+	online bool
+	queued int
+
+	// marked is the number of issues marked for a bulk action
+	marked int
+
+	// progress is the bulk action in progress, if any
+	progress *progress
 }
 
+// progress is how far a bulk action has got
+type progress struct {
+	task   string
+	done   int
+	total  int
+	failed int
+}
+
+// toastDuration is how long a toast is shown
+const toastDuration = 5 * time.Second
+
+// toastExpiredMsg is sent when the toast with ID id has been shown for
+// toastDuration
+type toastExpiredMsg struct{ id int }
+
 type Styles struct {
-	Footer lipgloss.Style
+	Footer  lipgloss.Style
+	Error   lipgloss.Style
+	Toast   lipgloss.Style
+	Online  lipgloss.Style
+	Offline lipgloss.Style
+	Queued  lipgloss.Style
+	Bar     lipgloss.Style
+	Failed  lipgloss.Style
 }
 
 func New() Model {
 	return Model{
 		height: 1,
 		styles: defaultStyles(),
+		online: true,
 	}
 }
 
@@ -28,6 +75,33 @@ func defaultStyles() Styles {
 			Background(lipgloss.Color("#303030")).
 			Foreground(lipgloss.Color("#FAFAFA")).
 			Padding(0, 1),
+		Error: lipgloss.NewStyle().
+			Background(lipgloss.Color("#FF5F87")).
+			Foreground(lipgloss.Color("#FAFAFA")).
+			Bold(true).
+			Padding(0, 1),
+		Toast: lipgloss.NewStyle().
+			Background(lipgloss.Color("#F2C94C")).
+			Foreground(lipgloss.Color("#1A1A1A")).
+			Bold(true).
+			Padding(0, 1),
+		Online: lipgloss.NewStyle().
+			Background(lipgloss.Color("#303030")).
+			Foreground(lipgloss.Color("#4CB782")),
+		Offline: lipgloss.NewStyle().
+			Background(lipgloss.Color("#303030")).
+			Foreground(lipgloss.Color("#F2C94C")).
+			Bold(true),
+		Queued: lipgloss.NewStyle().
+			Background(lipgloss.Color("#303030")).
+			Foreground(lipgloss.Color("#A0A0A0")),
+		Bar: lipgloss.NewStyle().
+			Background(lipgloss.Color("#303030")).
+			Foreground(lipgloss.Color("#874BFD")),
+		Failed: lipgloss.NewStyle().
+			Background(lipgloss.Color("#303030")).
+			Foreground(lipgloss.Color("#FF5F87")).
+			Bold(true),
 	}
 }
 
@@ -39,25 +113,122 @@ func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.WindowSizeMsg:
 		m.width = msg.Width
+	case tea.KeyMsg:
+		m.message = ""
+	case toastExpiredMsg:
+		if msg.id == m.toastID {
+			m.toast = ""
+		}
 	}
 	return m, nil
 }
 
 func (m Model) View() string {
-	help := "q: quit | tab: switch view | enter: select | esc: close detail"
-	
-	footerContent := lipgloss.NewStyle().
-		Width(m.width).
+	if m.message != "" {
+		return m.styles.Error.Width(m.width).MaxHeight(1).Render(m.message)
+	}
+	if m.progress != nil {
+		return m.renderProgress()
+	}
+	if m.toast != "" {
+		return m.styles.Toast.Width(m.width).MaxHeight(1).Render(m.toast)
+	}
+
+	help := "q: quit | tab: switch view | enter: select | n: new issue | T: team | /: filter | esc: close detail"
+
+	status := m.renderStatus()
+	helpWidth := max(m.width-m.styles.Footer.GetHorizontalFrameSize()-lipgloss.Width(status), 0)
+	footerContent := status + lipgloss.NewStyle().
+		Background(lipgloss.Color("#303030")).
+		Width(helpWidth).
+		MaxHeight(1).
 		Align(lipgloss.Center).
 		Render(help)
-	
+
 	return m.styles.Footer.Render(footerContent)
 }
 
+// renderStatus renders whether Linear can be reached and how many edits
+// are waiting to be sent
+func (m Model) renderStatus() string {
+	status := m.styles.Online.Render("● online")
+	if !m.online {
+		status = m.styles.Offline.Render("○ offline")
+	}
+	if m.queued > 0 {
+		status += m.styles.Queued.Render(fmt.Sprintf(" · %d queued", m.queued))
+	}
+	if m.marked > 0 {
+		status += m.styles.Queued.Render(fmt.Sprintf(" · %d selected", m.marked))
+	}
+	return status
+}
+
+// renderProgress renders the task of a bulk action with a bar filled in
+// proportion to the items done, and the number done and failed
+func (m Model) renderProgress() string {
+	p := m.progress
+	count := fmt.Sprintf(" %d/%d", p.done, p.total)
+	if p.failed > 0 {
+		count += m.styles.Failed.Render(fmt.Sprintf(" · %d failed", p.failed))
+	}
+	task := p.task + " "
+
+	width := m.width - m.styles.Footer.GetHorizontalFrameSize() - lipgloss.Width(task) - lipgloss.Width(count)
+	width = max(width, 10)
+	filled := 0
+	if p.total > 0 {
+		filled = width * p.done / p.total
+	}
+	bar := m.styles.Bar.Render(strings.Repeat("█", filled)) +
+		m.styles.Queued.Render(strings.Repeat("░", width-filled))
+
+	return m.styles.Footer.Width(m.width).MaxHeight(1).Render(task + bar + count)
+}
+
+// SetConnection shows whether Linear can be reached and the number of
+// edits in the outbox
+func (m *Model) SetConnection(online bool, queued int) {
+	m.online = online
+	m.queued = queued
+}
+
+// SetMarked shows the number of issues marked for a bulk action
+func (m *Model) SetMarked(marked int) {
+	m.marked = marked
+}
+
+// SetProgress shows the progress of a bulk action in place of the key help
+// and any toast: the task, and the number of items done and failed out of
+// total
+func (m *Model) SetProgress(task string, done, failed, total int) {
+	m.progress = &progress{task: task, done: done, total: total, failed: failed}
+	m.toast = ""
+}
+
+// ClearProgress hides the progress of a bulk action once it has finished
+func (m *Model) ClearProgress() {
+	m.progress = nil
+}
+
+// SetError shows an error until the next key press
+func (m *Model) SetError(message string) {
+	m.message = message
+}
+
+// Toast shows a notice for a few seconds, replacing any toast shown. The
+// returned command hides it.
+func (m *Model) Toast(message string) tea.Cmd {
+	m.toast = message
+	m.toastID++
+	id := m.toastID
+	return tea.Tick(toastDuration, func(time.Time) tea.Msg { return toastExpiredMsg{id: id} })
+}
+
 func (m *Model) SetWidth(width int) {
 	m.width = width
 }
 
 func (m *Model) SetHeight(height int) {
 	m.height = height
-}
\ No newline at end of file
+}
@@ -0,0 +1,595 @@
+package issueform
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textarea"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/linear-tui/linear-tui/internal/domain"
+	"github.com/linear-tui/linear-tui/internal/ui/components/picker"
+	"github.com/linear-tui/linear-tui/internal/ui/editor"
+	"github.com/linear-tui/linear-tui/internal/ui/messages"
+)
+
+// editorID identifies the description in EditorFinishedMsg
+const editorID = "description"
+
+// descriptionHeight is the number of lines of the description textarea
+const descriptionHeight = 5
+
+// labelWidth is the width of the field labels
+const labelWidth = 13
+
+type field int
+
+const (
+	fieldTitle field = iota
+	fieldDescription
+	fieldTeam
+	fieldStatus
+	fieldPriority
+	fieldAssignee
+	fieldProject
+	fieldLabels
+	fieldCount
+)
+
+var fieldNames = [fieldCount]string{
+	"Title", "Description", "Team", "Status", "Priority", "Assignee", "Project", "Labels",
+}
+
+// pickerID identifies the picker of a field in PickerClosedMsg
+func (f field) pickerID() string {
+	return "issueform." + strings.ToLower(fieldNames[f])
+}
+
+// Options holds the choices for the fields that do not depend on the team
+type Options struct {
+	// TeamID is the team selected when the form opens
+	TeamID string
+
+	// ParentID makes the new issue a sub-issue of the issue with this
+	// Linear ID, whose display ID is Parent
+	ParentID string
+	Parent   string
+
+	Teams     []picker.Option
+	Assignees []picker.Option
+	Projects  []picker.Option
+
+	// Priorities are identified by Linear priority number
+	Priorities []picker.Option
+}
+
+type Styles struct {
+	Box      lipgloss.Style
+	Title    lipgloss.Style
+	Label    lipgloss.Style
+	Focused  lipgloss.Style
+	Value    lipgloss.Style
+	Empty    lipgloss.Style
+	Input    lipgloss.Style
+	Hint     lipgloss.Style
+	Error    lipgloss.Style
+	LabelDot lipgloss.Style
+}
+
+// Model is a form for creating an issue. Text fields are edited in place;
+// the others are chosen with a picker. The team's states and labels are
+// requested with an IssueFormTeamChangedMsg whenever the team changes and
+// supplied with SetTeamOptions.
+type Model struct {
+	title       textinput.Model
+	description textarea.Model
+
+	options Options
+	states  []picker.Option
+	labels  []picker.Option
+
+	// teamLoading is set while the states and labels of the team are
+	// being fetched
+	teamLoading bool
+	teamErr     error
+
+	teamID     string
+	stateID    string
+	priority   string
+	assigneeID string
+	projectID  string
+	labelIDs   []string
+
+	focus      field
+	picker     *picker.Model
+	errs       map[field]string
+	err        error
+	active     bool
+	inEditor   bool
+	submitting bool
+
+	width  int
+	height int
+	styles Styles
+}
+
+func New() Model {
+	title := textinput.New()
+	title.Prompt = ""
+	title.Placeholder = "Issue title"
+
+	description := textarea.New()
+	description.Placeholder = "Add a description in markdown..."
+	description.ShowLineNumbers = false
+	description.CharLimit = 0
+	description.SetHeight(descriptionHeight)
+
+	return Model{
+		title:       title,
+		description: description,
+		errs:        make(map[field]string),
+		width:       70,
+		height:      24,
+		styles:      defaultStyles(),
+	}
+}
+
+func defaultStyles() Styles {
+	return Styles{
+		Box: lipgloss.NewStyle().
+			BorderStyle(lipgloss.RoundedBorder()).
+			BorderForeground(lipgloss.Color("#874BFD")).
+			Padding(0, 1),
+		Title: lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#874BFD")).
+			Bold(true),
+		Label: lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#626262")).
+			Width(labelWidth),
+		Focused: lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#874BFD")).
+			Bold(true).
+			Width(labelWidth),
+		Value: lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#FAFAFA")),
+		Empty: lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#626262")).
+			Italic(true),
+		Input: lipgloss.NewStyle().
+			BorderStyle(lipgloss.RoundedBorder()).
+			BorderForeground(lipgloss.Color("#626262")),
+		Hint: lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#626262")).
+			Italic(true),
+		Error: lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#FF5F87")),
+		LabelDot: lipgloss.NewStyle(),
+	}
+}
+
+func (m Model) Init() tea.Cmd {
+	return nil
+}
+
+// Open starts a new issue with empty fields
+func (m *Model) Open(options Options) tea.Cmd {
+	m.options = options
+	m.title.Reset()
+	m.description.Reset()
+	m.priority = "0"
+	m.assigneeID = ""
+	m.projectID = ""
+	m.picker = nil
+	m.errs = make(map[field]string)
+	m.err = nil
+	m.active = true
+	m.inEditor = false
+	m.submitting = false
+
+	m.focus = fieldTitle
+	m.description.Blur()
+	return tea.Batch(m.title.Focus(), m.setTeam(options.TeamID))
+}
+
+// setTeam selects a team, clearing the fields that depend on it, and
+// requests its states and labels
+func (m *Model) setTeam(teamID string) tea.Cmd {
+	m.teamID = teamID
+	m.stateID = ""
+	m.labelIDs = nil
+	m.states = nil
+	m.labels = nil
+	m.teamErr = nil
+	m.teamLoading = teamID != ""
+	if teamID == "" {
+		return nil
+	}
+	return func() tea.Msg {
+		return messages.IssueFormTeamChangedMsg{TeamID: teamID}
+	}
+}
+
+// SetTeamOptions supplies the states and labels of a team, or the error
+// fetching them. Options for a team other than the selected one are
+// ignored.
+func (m *Model) SetTeamOptions(teamID string, states, labels []picker.Option, err error) {
+	if !m.active || teamID != m.teamID {
+		return
+	}
+	m.teamLoading = false
+	m.teamErr = err
+	m.states = states
+	m.labels = labels
+}
+
+func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
+	if !m.active {
+		return m, nil
+	}
+
+	switch msg := msg.(type) {
+	case messages.PickerClosedMsg:
+		return m.pickerClosed(msg)
+
+	case messages.EditorFinishedMsg:
+		if msg.ID != editorID || !m.inEditor {
+			return m, nil
+		}
+		m.inEditor = false
+		if msg.Err != nil {
+			m.err = msg.Err
+		} else {
+			m.description.SetValue(strings.TrimRight(msg.Text, "\n"))
+		}
+		return m, m.description.Focus()
+
+	case messages.IssueCreatedMsg:
+		if !m.submitting {
+			return m, nil
+		}
+		m.submitting = false
+		if msg.Err != nil {
+			// Keep the fields so it can be retried
+			m.err = msg.Err
+			return m, nil
+		}
+		m.close()
+		return m, nil
+
+	case tea.KeyMsg:
+		if m.submitting || m.inEditor {
+			return m, nil
+		}
+		if m.picker != nil {
+			var cmd tea.Cmd
+			*m.picker, cmd = m.picker.Update(msg)
+			return m, cmd
+		}
+		return m.handleKey(msg)
+	}
+
+	if m.picker != nil {
+		var cmd tea.Cmd
+		*m.picker, cmd = m.picker.Update(msg)
+		return m, cmd
+	}
+	return m.updateInput(msg)
+}
+
+func (m Model) handleKey(msg tea.KeyMsg) (Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc":
+		m.close()
+		return m, nil
+	case "ctrl+s":
+		return m.submit()
+	case "tab":
+		return m, m.setFocus((m.focus + 1) % fieldCount)
+	case "shift+tab":
+		return m, m.setFocus((m.focus + fieldCount - 1) % fieldCount)
+	case "ctrl+e":
+		if m.focus == fieldDescription {
+			m.inEditor = true
+			return m, editor.Open(editorID, m.description.Value())
+		}
+	case "enter":
+		if m.focus == fieldTitle {
+			return m, m.setFocus(fieldDescription)
+		}
+		if m.focus != fieldDescription {
+			return m, m.openPicker()
+		}
+	case "down", "j":
+		if m.focus > fieldDescription {
+			return m, m.setFocus(min(m.focus+1, fieldCount-1))
+		}
+	case "up", "k":
+		if m.focus > fieldDescription {
+			return m, m.setFocus(m.focus - 1)
+		}
+	}
+
+	return m.updateInput(msg)
+}
+
+// updateInput passes a message to the text field being edited
+func (m Model) updateInput(msg tea.Msg) (Model, tea.Cmd) {
+	var cmd tea.Cmd
+	switch m.focus {
+	case fieldTitle:
+		m.title, cmd = m.title.Update(msg)
+		if strings.TrimSpace(m.title.Value()) != "" {
+			delete(m.errs, fieldTitle)
+		}
+	case fieldDescription:
+		m.description, cmd = m.description.Update(msg)
+	}
+	return m, cmd
+}
+
+func (m *Model) setFocus(f field) tea.Cmd {
+	m.focus = f
+	m.title.Blur()
+	m.description.Blur()
+	switch f {
+	case fieldTitle:
+		return m.title.Focus()
+	case fieldDescription:
+		return m.description.Focus()
+	}
+	return nil
+}
+
+// openPicker opens the picker for the focused field
+func (m *Model) openPicker() tea.Cmd {
+	var options []picker.Option
+	var selected []string
+	multi := false
+
+	switch m.focus {
+	case fieldTeam:
+		options, selected = m.options.Teams, []string{m.teamID}
+	case fieldStatus:
+		if m.teamLoading || len(m.states) == 0 {
+			return nil
+		}
+		options, selected = m.states, []string{m.stateID}
+	case fieldPriority:
+		options, selected = m.options.Priorities, []string{m.priority}
+	case fieldAssignee:
+		options = append([]picker.Option{{Label: "Unassigned"}}, m.options.Assignees...)
+		selected = []string{m.assigneeID}
+	case fieldProject:
+		options = append([]picker.Option{{Label: "No project"}}, m.options.Projects...)
+		selected = []string{m.projectID}
+	case fieldLabels:
+		if m.teamLoading || len(m.labels) == 0 {
+			return nil
+		}
+		options, selected, multi = m.labels, m.labelIDs, true
+	default:
+		return nil
+	}
+
+	p := picker.New(m.focus.pickerID(), fieldNames[m.focus], options, multi)
+	p.Select(selected...)
+	p.SetSize(m.width, min(m.height, 20))
+	m.picker = &p
+	return p.Focus()
+}
+
+func (m Model) pickerClosed(msg messages.PickerClosedMsg) (Model, tea.Cmd) {
+	if m.picker == nil || msg.ID != m.picker.ID() {
+		return m, nil
+	}
+	m.picker = nil
+	if msg.Canceled {
+		return m, nil
+	}
+
+	var value string
+	if len(msg.Selected) > 0 {
+		value = msg.Selected[0]
+	}
+
+	var cmd tea.Cmd
+	switch m.focus {
+	case fieldTeam:
+		if value != m.teamID {
+			cmd = m.setTeam(value)
+		}
+		delete(m.errs, fieldTeam)
+	case fieldStatus:
+		m.stateID = value
+	case fieldPriority:
+		m.priority = value
+	case fieldAssignee:
+		m.assigneeID = value
+	case fieldProject:
+		m.projectID = value
+	case fieldLabels:
+		m.labelIDs = msg.Selected
+	}
+	return m, cmd
+}
+
+// submit validates the fields and sends the issue to be created
+func (m Model) submit() (Model, tea.Cmd) {
+	m.errs = make(map[field]string)
+	title := strings.TrimSpace(m.title.Value())
+	if title == "" {
+		m.errs[fieldTitle] = "A title is required"
+	}
+	if m.teamID == "" {
+		m.errs[fieldTeam] = "A team is required"
+	}
+	if len(m.errs) > 0 {
+		return m, nil
+	}
+
+	priority, _ := strconv.Atoi(m.priority)
+	issue := domain.NewIssue{
+		Title:       title,
+		Description: strings.TrimSpace(m.description.Value()),
+		TeamID:      m.teamID,
+		StateID:     m.stateID,
+		Priority:    priority,
+		AssigneeID:  m.assigneeID,
+		ProjectID:   m.projectID,
+		LabelIDs:    m.labelIDs,
+		ParentID:    m.options.ParentID,
+	}
+
+	m.submitting = true
+	m.err = nil
+	return m, func() tea.Msg {
+		return messages.IssueSubmittedMsg{Issue: issue}
+	}
+}
+
+func (m *Model) close() {
+	m.active = false
+	m.picker = nil
+	m.title.Blur()
+	m.description.Blur()
+}
+
+func (m Model) View() string {
+	if !m.active {
+		return ""
+	}
+	if m.picker != nil {
+		return m.picker.View()
+	}
+
+	innerWidth := max(m.width-m.styles.Box.GetHorizontalFrameSize(), 1)
+	title := "New issue"
+	if m.options.Parent != "" {
+		title = "New sub-issue of " + m.options.Parent
+	}
+	lines := []string{m.styles.Title.Render(title), ""}
+
+	for f := field(0); f < fieldCount; f++ {
+		lines = append(lines, m.renderField(f, innerWidth))
+		if err, ok := m.errs[f]; ok {
+			lines = append(lines, strings.Repeat(" ", labelWidth)+m.styles.Error.Render(err))
+		}
+	}
+
+	lines = append(lines, "", m.renderStatus(innerWidth))
+	return m.styles.Box.Width(max(m.width-m.styles.Box.GetHorizontalBorderSize(), 1)).Render(strings.Join(lines, "\n"))
+}
+
+func (m Model) renderField(f field, width int) string {
+	labelStyle := m.styles.Label
+	if f == m.focus {
+		labelStyle = m.styles.Focused
+	}
+	label := labelStyle.Render(fieldNames[f])
+	valueWidth := max(width-labelWidth, 1)
+
+	var value string
+	switch f {
+	case fieldTitle:
+		value = m.title.View()
+	case fieldDescription:
+		value = m.styles.Input.Render(m.description.View())
+	case fieldTeam:
+		value = m.renderChoice(m.options.Teams, m.teamID, "Choose a team")
+	case fieldStatus:
+		value = m.renderTeamChoice(m.states, m.stateID, "Default")
+	case fieldPriority:
+		value = m.renderChoice(m.options.Priorities, m.priority, "No priority")
+	case fieldAssignee:
+		value = m.renderChoice(m.options.Assignees, m.assigneeID, "Unassigned")
+	case fieldProject:
+		value = m.renderChoice(m.options.Projects, m.projectID, "No project")
+	case fieldLabels:
+		value = m.renderLabels()
+	}
+
+	return lipgloss.JoinHorizontal(lipgloss.Top, label, lipgloss.NewStyle().MaxWidth(valueWidth).Render(value))
+}
+
+// renderChoice renders the label of the chosen option, or placeholder if
+// none is chosen
+func (m Model) renderChoice(options []picker.Option, id, placeholder string) string {
+	for _, option := range options {
+		if option.ID == id {
+			return m.styles.Value.Render(option.Label)
+		}
+	}
+	return m.styles.Empty.Render(placeholder)
+}
+
+// renderTeamChoice renders a choice among options that depend on the team
+func (m Model) renderTeamChoice(options []picker.Option, id, placeholder string) string {
+	switch {
+	case m.teamID == "":
+		return m.styles.Empty.Render("Choose a team first")
+	case m.teamLoading:
+		return m.styles.Empty.Render("Loading...")
+	case m.teamErr != nil:
+		return m.styles.Error.Render("Could not load: " + m.teamErr.Error())
+	}
+	return m.renderChoice(options, id, placeholder)
+}
+
+func (m Model) renderLabels() string {
+	if len(m.labelIDs) == 0 {
+		return m.renderTeamChoice(m.labels, "", "None")
+	}
+
+	chosen := make(map[string]bool, len(m.labelIDs))
+	for _, id := range m.labelIDs {
+		chosen[id] = true
+	}
+
+	var parts []string
+	for _, label := range m.labels {
+		if !chosen[label.ID] {
+			continue
+		}
+		dot := ""
+		if label.Color != "" {
+			dot = m.styles.LabelDot.Foreground(lipgloss.Color(label.Color)).Render("● ")
+		}
+		parts = append(parts, dot+m.styles.Value.Render(label.Label))
+	}
+	return strings.Join(parts, "  ")
+}
+
+func (m Model) renderStatus(width int) string {
+	switch {
+	case m.submitting:
+		return m.styles.Hint.Render("Creating issue...")
+	case m.inEditor:
+		return m.styles.Hint.Render("Editing description in external editor...")
+	case m.err != nil:
+		return m.styles.Error.Width(width).Render(m.err.Error())
+	case m.focus == fieldDescription:
+		return m.styles.Hint.Render("ctrl+e: open in $EDITOR | tab: next | ctrl+s: create | esc: cancel")
+	case m.focus > fieldDescription:
+		return m.styles.Hint.Render("enter: choose | tab: next | ctrl+s: create | esc: cancel")
+	}
+	return m.styles.Hint.Render("tab: next | ctrl+s: create | esc: cancel")
+}
+
+// Active reports whether the form is open
+func (m Model) Active() bool {
+	return m.active
+}
+
+// SetSize sets the space the form may take, including its border
+func (m *Model) SetSize(width, height int) {
+	m.width = width
+	m.height = height
+
+	inputWidth := max(width-m.styles.Box.GetHorizontalFrameSize()-labelWidth, 1)
+	m.title.Width = inputWidth - 1
+	m.description.SetWidth(max(inputWidth-m.styles.Input.GetHorizontalFrameSize(), 1))
+	if m.picker != nil {
+		m.picker.SetSize(width, min(height, 20))
+	}
+}
@@ -0,0 +1,189 @@
+package listview
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/table"
+	"github.com/linear-tui/linear-tui/internal/domain"
+	"github.com/linear-tui/linear-tui/internal/ui/messages"
+)
+
+// column describes a table column. Columns with a zero width share the
+// space left over by the fixed columns.
+type column struct {
+	title    string
+	width    int
+	minWidth int
+}
+
+var issueColumns = []column{
+	{title: "ID", width: 10},
+	{title: "Title", minWidth: 20},
+	{title: "Labels", width: 20},
+	{title: "Status", width: 14},
+	{title: "Priority", width: 8},
+	{title: "Assignee", width: 16},
+	{title: "Age", width: 4},
+}
+
+var projectColumns = []column{
+	{title: "Name", minWidth: 20},
+	{title: "State", width: 12},
+	{title: "Progress", width: 8},
+	{title: "Target", width: 12},
+}
+
+var notificationColumns = []column{
+	{title: "", width: 1},
+	{title: "Issue", width: 10},
+	{title: "Title", minWidth: 20},
+	{title: "Reason", width: 18},
+	{title: "From", width: 16},
+	{title: "Age", width: 4},
+}
+
+var cycleColumns = []column{
+	{title: "Cycle", minWidth: 12},
+	{title: "Team", width: 12},
+	{title: "Status", width: 8},
+	{title: "Dates", width: 15},
+	{title: "Scope", width: 5},
+	{title: "Done", width: 5},
+	{title: "Left", width: 5},
+	{title: "Progress", width: 8},
+}
+
+// labelsColumn is the column of issueColumns that shows labels, which are
+// rendered as colored chips rather than as text
+const labelsColumn = 2
+
+// cellPadding is the horizontal padding around each cell
+const cellPadding = 2
+
+func columnsFor(viewType messages.ViewType) []column {
+	switch viewType {
+	case messages.ProjectView:
+		return projectColumns
+	case messages.InboxView:
+		return notificationColumns
+	case messages.CycleView:
+		return cycleColumns
+	}
+	return issueColumns
+}
+
+// layoutColumns sizes the columns to fill the given width
+func layoutColumns(cols []column, width int) []table.Column {
+	fixed := 0
+	flexible := 0
+	for _, col := range cols {
+		fixed += col.width + cellPadding
+		if col.width == 0 {
+			flexible++
+		}
+	}
+
+	flexWidth := 0
+	if flexible > 0 {
+		flexWidth = (width - fixed) / flexible
+	}
+
+	result := make([]table.Column, len(cols))
+	for i, col := range cols {
+		w := col.width
+		if w == 0 {
+			w = max(flexWidth, col.minWidth)
+		}
+		result[i] = table.Column{Title: col.title, Width: w}
+	}
+	return result
+}
+
+func issueRow(issue domain.Issue) table.Row {
+	return table.Row{
+		issue.ID,
+		issue.Title,
+		labelNames(issue.Labels),
+		issue.Status,
+		issue.Priority,
+		issue.Assignee,
+		formatAge(issue.CreatedAt),
+	}
+}
+
+func projectRow(project domain.Project) table.Row {
+	target := "-"
+	if !project.TargetDate.IsZero() {
+		target = project.TargetDate.Format("Jan 02 2006")
+	}
+
+	return table.Row{
+		project.Name,
+		project.Status,
+		fmt.Sprintf("%.0f%%", project.Progress*100),
+		target,
+	}
+}
+
+// notificationRow shows unread notifications with a dot in the first column
+func notificationRow(notification domain.Notification) table.Row {
+	unread := ""
+	if !notification.Read {
+		unread = "●"
+	}
+
+	return table.Row{
+		unread,
+		notification.IssueIdentifier,
+		notification.IssueTitle,
+		notification.Reason,
+		notification.Actor,
+		formatAge(notification.CreatedAt),
+	}
+}
+
+// cycleRow shows the number of issues in a cycle, completed and remaining
+func cycleRow(cycle domain.Cycle) table.Row {
+	return table.Row{
+		cycle.Name,
+		cycle.Team,
+		cycle.Status,
+		cycle.StartsAt.Format("Jan 02") + " – " + cycle.EndsAt.Format("Jan 02"),
+		fmt.Sprint(cycle.Scope),
+		fmt.Sprint(cycle.Completed),
+		fmt.Sprint(cycle.Scope - cycle.Completed),
+		fmt.Sprintf("%.0f%%", cycle.Progress*100),
+	}
+}
+
+// labelNames lists the names of labels, as the text of the labels column
+func labelNames(labels []domain.Label) string {
+	names := make([]string, len(labels))
+	for i, label := range labels {
+		names[i] = label.Name
+	}
+	return strings.Join(names, ", ")
+}
+
+// formatAge formats the time since t in a compact form such as "5d"
+func formatAge(t time.Time) string {
+	if t.IsZero() {
+		return "-"
+	}
+
+	d := time.Since(t)
+	switch {
+	case d < time.Hour:
+		return fmt.Sprintf("%dm", int(d.Minutes()))
+	case d < 24*time.Hour:
+		return fmt.Sprintf("%dh", int(d.Hours()))
+	case d < 30*24*time.Hour:
+		return fmt.Sprintf("%dd", int(d.Hours()/24))
+	case d < 365*24*time.Hour:
+		return fmt.Sprintf("%dmo", int(d.Hours()/(24*30)))
+	default:
+		return fmt.Sprintf("%dy", int(d.Hours()/(24*365)))
+	}
+}
@@ -0,0 +1,84 @@
+package listview
+
+import (
+	"sort"
+
+	"github.com/linear-tui/linear-tui/internal/linear"
+	"github.com/linear-tui/linear-tui/internal/ui/messages"
+)
+
+// The My Issues view groups its issues by workflow state, in the order
+// issues move through the workflow. Each group starts with a heading line
+// that is not a row of the table, so the cursor skips it.
+
+// grouped reports whether the rows of the current view are grouped
+func (m Model) grouped() bool {
+	return m.viewType == messages.MyIssuesView
+}
+
+// sortByGroup orders the visible rows by group, keeping the order of the
+// rows within each group
+func (m *Model) sortByGroup() {
+	order := make([]int, len(m.visible))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool {
+		x, y := m.issues[m.visible[order[a]]], m.issues[m.visible[order[b]]]
+		if tx, ty := linear.StateTypeOrder(x.StateType), linear.StateTypeOrder(y.StateType); tx != ty {
+			return tx < ty
+		}
+		return x.Status < y.Status
+	})
+
+	visible := make([]int, len(order))
+	var highlights []cellMatches
+	if m.highlights != nil {
+		highlights = make([]cellMatches, len(order))
+	}
+	for i, j := range order {
+		visible[i] = m.visible[j]
+		if highlights != nil {
+			highlights[i] = m.highlights[j]
+		}
+	}
+	m.visible, m.highlights = visible, highlights
+}
+
+// groupOf returns the group of a row
+func (m Model) groupOf(row int) string {
+	return m.issues[m.visible[row]].Status
+}
+
+// startsGroup reports whether a heading is shown above a row: the first row
+// of each group, and the first row shown so that it is never headless
+func (m Model) startsGroup(row int) bool {
+	return m.grouped() && (row == m.offset || m.groupOf(row) != m.groupOf(row-1))
+}
+
+// groupSize returns the number of rows in the group of a row
+func (m Model) groupSize(row int) int {
+	group, size := m.groupOf(row), 0
+	for i := range m.visible {
+		if m.groupOf(i) == group {
+			size++
+		}
+	}
+	return size
+}
+
+// linesThrough returns the number of lines the rows from first to last take
+// up when first is the first row shown, counting group headings
+func (m Model) linesThrough(first, last int) int {
+	if !m.grouped() {
+		return last - first + 1
+	}
+	lines := 0
+	for row := first; row <= last; row++ {
+		if row == first || m.groupOf(row) != m.groupOf(row-1) {
+			lines++
+		}
+		lines++
+	}
+	return lines
+}
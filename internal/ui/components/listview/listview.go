@@ -1,35 +1,99 @@
 package listview
 
 import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/bubbles/table"
-	"github.com/charmbracelet/bubbles/viewport"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/linear-tui/linear-tui/internal/domain"
 	"github.com/linear-tui/linear-tui/internal/ui/messages"
 )
 
 type Styles struct {
 	EmptyState lipgloss.Style
-	ListItem   lipgloss.Style
+	Header     lipgloss.Style
+	Group      lipgloss.Style
+	GroupCount lipgloss.Style
+	Cell       lipgloss.Style
 	Selected   lipgloss.Style
+	Match      lipgloss.Style
+	Marked     lipgloss.Style
+	Mark       lipgloss.Style
 }
 
+// loadMoreThreshold is how close to the last row the cursor gets before
+// the next page is requested
+const loadMoreThreshold = 10
+
 type Model struct {
-	items    []string
-	cursor   int
-	focused  bool
-	viewport viewport.Model
-	table    table.Model
-	viewType messages.ViewType
-	styles   Styles
+	issues      []domain.Issue
+	projects    []domain.Project
+	nextCursor  string
+	loadingMore bool
+	focused     bool
+	width       int
+	height      int
+	table       table.Model
+	viewType    messages.ViewType
+	styles      Styles
+
+	// notifications are the items of the inbox
+	notifications []domain.Notification
+
+	// cycles are the items of the cycles view
+	cycles []domain.Cycle
+
+	// search is the text rows are fuzzy matched against. visible holds the
+	// indexes of the items shown, in display order, and highlights the
+	// matched characters of each shown row.
+	search     string
+	visible    []int
+	highlights []cellMatches
+
+	// offset is the first row shown
+	offset int
+
+	// err is why the first page of the view could not be loaded, if so
+	err error
+
+	// tree shows the issue list as a tree of sub-issues. depths holds the
+	// depth of each shown row, children the indexes of the loaded
+	// sub-issues of each issue, and collapsed the Linear IDs of the issues
+	// whose sub-issues are hidden.
+	tree      bool
+	depths    []int
+	children  map[int][]int
+	collapsed map[string]bool
+
+	// marked holds the Linear IDs of the issues marked for a bulk action,
+	// and anchor the one toggled last, where a range starts. markingAll is
+	// set while the pages of a filter are loaded to be marked.
+	marked     map[string]bool
+	anchor     string
+	markingAll bool
 }
 
 func New() Model {
-	vp := viewport.New(80, 20)
+	styles := defaultStyles()
+
+	t := table.New(
+		table.WithColumns(layoutColumns(issueColumns, 80)),
+		table.WithKeyMap(keyMap()),
+		table.WithStyles(table.Styles{
+			Header:   styles.Header,
+			Cell:     styles.Cell,
+			Selected: styles.Selected,
+		}),
+	)
+
 	return Model{
-		viewport: vp,
-		items:    []string{},
-		styles:   defaultStyles(),
+		width:  80,
+		height: 20,
+		table:  t,
+		styles: styles,
 	}
 }
 
@@ -38,20 +102,92 @@ func defaultStyles() Styles {
 		EmptyState: lipgloss.NewStyle().
 			Foreground(lipgloss.Color("#626262")).
 			Italic(true),
-		ListItem: lipgloss.NewStyle().
+		Header: lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#626262")).
+			BorderStyle(lipgloss.NormalBorder()).
+			BorderBottom(true).
+			BorderForeground(lipgloss.Color("#626262")).
+			Bold(true).
+			Padding(0, 1),
+		Group: lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#874BFD")).
+			Bold(true).
+			Padding(0, 1),
+		GroupCount: lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#626262")),
+		Cell: lipgloss.NewStyle().
 			Foreground(lipgloss.Color("#FAFAFA")),
 		Selected: lipgloss.NewStyle().
 			Foreground(lipgloss.Color("#FAFAFA")).
 			Background(lipgloss.Color("#874BFD")).
 			Bold(true),
+		Match: lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#F5C542")).
+			Underline(true),
+		Marked: lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#FAFAFA")).
+			Background(lipgloss.Color("#3B3356")),
+		Mark: lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#F5C542")).
+			Bold(true),
 	}
 }
 
+// keyMap returns the table navigation keys, leaving single letters other
+// than j/k/g/G free for actions
+func keyMap() table.KeyMap {
+	km := table.DefaultKeyMap()
+	km.PageUp = key.NewBinding(key.WithKeys("pgup"))
+	km.PageDown = key.NewBinding(key.WithKeys("pgdown"))
+	km.HalfPageUp = key.NewBinding(key.WithKeys("ctrl+u"))
+	km.HalfPageDown = key.NewBinding(key.WithKeys("ctrl+d"))
+	return km
+}
+
 func (m Model) Init() tea.Cmd {
 	return nil
 }
 
 func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
+	// Data and search changes arrive regardless of focus
+	switch msg := msg.(type) {
+	case messages.DataLoadedMsg:
+		if msg.View == m.viewType && msg.Err != nil {
+			// A further page that failed leaves the rows loaded as they
+			// are, and is tried again as the cursor moves
+			m.loadingMore = false
+			if !msg.Append {
+				m.err = msg.Err
+			}
+			return m, nil
+		}
+		if msg.View == m.viewType {
+			m.err = nil
+			if msg.Append {
+				m.appendData(msg.Items)
+			} else {
+				// A view reloaded in place, such as after a sync, keeps
+				// the item under the cursor selected
+				selected := m.SelectedItem()
+				m.setData(msg.Items)
+				if selected != nil {
+					m.Select(selected)
+				}
+			}
+			m.nextCursor = msg.NextCursor
+			m.loadingMore = false
+			if m.markingAll {
+				m.markVisible()
+				m.markingAll = m.nextCursor != ""
+			}
+		}
+		return m, m.loadMore()
+
+	case messages.SearchChangedMsg:
+		m.SetSearch(msg.Text)
+		return m, nil
+	}
+
 	if !m.focused {
 		return m, nil
 	}
@@ -59,45 +195,344 @@ func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
 		switch msg.String() {
-		case "up", "k":
-			m.cursor = (m.cursor - 1 + len(m.items)) % len(m.items)
-		case "down", "j":
-			m.cursor = (m.cursor + 1) % len(m.items)
 		case "enter":
-			if m.cursor < len(m.items) {
+			if item := m.SelectedItem(); item != nil {
 				return m, func() tea.Msg {
-					return messages.ItemSelectedMsg{Item: m.items[m.cursor]}
+					return messages.ItemSelectedMsg{Item: item}
 				}
 			}
+			return m, nil
+		case "t":
+			if m.viewType == messages.IssueView {
+				m.toggleTree()
+			}
+			return m, nil
+		case "left":
+			m.setCollapsed(true)
+			return m, nil
+		case "right":
+			m.setCollapsed(false)
+			return m, nil
+		case " ":
+			if m.markable() {
+				m.toggleMark()
+			}
+			return m, nil
+		case "V":
+			if m.markable() {
+				m.markRange()
+			}
+			return m, nil
+		case "ctrl+a":
+			if m.markable() {
+				m.markAll()
+				return m, m.loadMore()
+			}
+			return m, nil
 		}
 	}
 
 	var cmd tea.Cmd
-	m.viewport, cmd = m.viewport.Update(msg)
-	return m, cmd
+	m.table, cmd = m.table.Update(msg)
+	m.scrollToCursor()
+	return m, tea.Batch(cmd, m.loadMore())
+}
+
+// loadMore requests the next page once the selected item nears the last
+// loaded item. While searching, the position among all loaded items is
+// used, so that a search matching few rows does not page through the
+// whole workspace. Every page is requested while marking all issues.
+func (m *Model) loadMore() tea.Cmd {
+	if m.nextCursor == "" || m.loadingMore {
+		return nil
+	}
+	index, ok := m.selectedIndex()
+	if !m.markingAll && (!ok || index < m.itemCount()-loadMoreThreshold) {
+		return nil
+	}
+
+	m.loadingMore = true
+	view, cursor := m.viewType, m.nextCursor
+	return func() tea.Msg {
+		return messages.LoadMoreMsg{View: view, Cursor: cursor}
+	}
 }
 
 func (m Model) View() string {
-	if len(m.items) == 0 {
+	if len(m.visible) == 0 {
 		return m.renderEmptyState()
 	}
 
-	return m.table.View()
+	return m.renderTable()
+}
+
+// SelectedItem returns the typed item under the cursor, or nil if the list
+// is empty
+func (m Model) SelectedItem() interface{} {
+	index, ok := m.selectedIndex()
+	if !ok {
+		return nil
+	}
+	switch m.viewType {
+	case messages.ProjectView:
+		return m.projects[index]
+	case messages.InboxView:
+		return m.notifications[index]
+	case messages.CycleView:
+		return m.cycles[index]
+	}
+	return m.issues[index]
+}
+
+// selectedIndex returns the index among the loaded items of the item under
+// the cursor
+func (m Model) selectedIndex() (int, bool) {
+	cursor := m.table.Cursor()
+	if cursor < 0 || cursor >= len(m.visible) {
+		return 0, false
+	}
+	return m.visible[cursor], true
+}
+
+// SetSearch fuzzy filters the rows by text, best match first. An empty
+// text shows every row.
+func (m *Model) SetSearch(text string) {
+	text = strings.TrimSpace(text)
+	if text == m.search {
+		return
+	}
+	m.search = text
+	m.refresh()
+	m.table.GotoTop()
+	m.scrollToCursor()
+}
+
+// SetView switches the list to a view and replaces its items, unmarking
+// every issue. nextCursor is the cursor for the view's next page, if any.
+func (m *Model) SetView(viewType messages.ViewType, items []interface{}, nextCursor string) {
+	m.ClearMarks()
+	m.err = nil
+	m.viewType = viewType
+	m.nextCursor = nextCursor
+	m.loadingMore = false
+	m.setData(items)
+	m.table.GotoTop()
+	m.scrollToCursor()
+}
+
+// SetError shows why the view could not be loaded while it has no rows
+func (m *Model) SetError(err error) {
+	m.err = err
+}
+
+// InsertItem adds an item to the top of the current view and selects it
+func (m *Model) InsertItem(item interface{}) {
+	m.setData(append([]interface{}{item}, m.items()...))
+	for i, index := range m.visible {
+		if index == 0 {
+			m.table.SetCursor(i)
+			break
+		}
+	}
+	m.scrollToCursor()
+}
+
+// UpdateItem replaces the loaded item with the same ID as item, if any
+func (m *Model) UpdateItem(item interface{}) {
+	items := m.items()
+	for i, existing := range items {
+		if itemKey(existing) == itemKey(item) {
+			items[i] = item
+			m.setData(items)
+			return
+		}
+	}
+}
+
+// RemoveItem removes the loaded item with the same ID as item, if any
+func (m *Model) RemoveItem(item interface{}) {
+	items := m.items()
+	for i, existing := range items {
+		if itemKey(existing) == itemKey(item) {
+			m.setData(append(items[:i], items[i+1:]...))
+			return
+		}
+	}
+}
+
+// Select moves the cursor to the loaded item with the same ID as item, if
+// it is shown
+func (m *Model) Select(item interface{}) {
+	items := m.items()
+	for i, index := range m.visible {
+		if itemKey(items[index]) == itemKey(item) {
+			m.table.SetCursor(i)
+			m.scrollToCursor()
+			return
+		}
+	}
+}
+
+func (m *Model) setData(items []interface{}) {
+	m.issues = nil
+	m.projects = nil
+	m.notifications = nil
+	m.cycles = nil
+	for _, item := range items {
+		switch item := item.(type) {
+		case domain.Issue:
+			m.issues = append(m.issues, item)
+		case domain.Project:
+			m.projects = append(m.projects, item)
+		case domain.Notification:
+			m.notifications = append(m.notifications, item)
+		case domain.Cycle:
+			m.cycles = append(m.cycles, item)
+		}
+	}
+	m.pruneMarks()
+	m.refresh()
+}
+
+// refresh rebuilds the table rows from the loaded items and the search
+func (m *Model) refresh() {
+	if m.search != "" {
+		m.visible, m.highlights = fuzzyFilter(m.search, m.searchFields(), m.itemCount())
+	} else {
+		m.visible = make([]int, m.itemCount())
+		for i := range m.visible {
+			m.visible[i] = i
+		}
+		m.highlights = nil
+	}
+	if m.grouped() {
+		m.sortByGroup()
+	}
+	if m.treed() {
+		m.arrangeTree()
+	}
+
+	rows := make([]table.Row, len(m.visible))
+	for i, index := range m.visible {
+		switch m.viewType {
+		case messages.ProjectView:
+			rows[i] = projectRow(m.projects[index])
+		case messages.InboxView:
+			rows[i] = notificationRow(m.notifications[index])
+		case messages.CycleView:
+			rows[i] = cycleRow(m.cycles[index])
+		default:
+			rows[i] = issueRow(m.issues[index])
+			if m.treed() {
+				rows[i][1] = m.treeTitle(i)
+			}
+		}
+	}
+
+	// Clear rows before columns may change so the table never renders a
+	// row with a different number of cells than it has columns
+	m.table.SetRows(nil)
+	m.table.SetColumns(layoutColumns(columnsFor(m.viewType), m.width))
+	m.table.SetRows(rows)
+	if m.table.Cursor() >= len(rows) {
+		m.table.SetCursor(max(len(rows)-1, 0))
+	}
+	m.scrollToCursor()
+}
+
+// appendData appends a further page of items, skipping any already loaded
+func (m *Model) appendData(items []interface{}) {
+	existing := m.items()
+	seen := make(map[string]bool, len(existing))
+	for _, item := range existing {
+		seen[itemKey(item)] = true
+	}
+
+	for _, item := range items {
+		if !seen[itemKey(item)] {
+			existing = append(existing, item)
+		}
+	}
+	m.setData(existing)
+}
+
+// items returns the items of the current view
+func (m Model) items() []interface{} {
+	var items []interface{}
+	switch m.viewType {
+	case messages.ProjectView:
+		for _, project := range m.projects {
+			items = append(items, project)
+		}
+	case messages.InboxView:
+		for _, notification := range m.notifications {
+			items = append(items, notification)
+		}
+	case messages.CycleView:
+		for _, cycle := range m.cycles {
+			items = append(items, cycle)
+		}
+	default:
+		for _, issue := range m.issues {
+			items = append(items, issue)
+		}
+	}
+	return items
+}
+
+func itemKey(item interface{}) string {
+	switch item := item.(type) {
+	case domain.Issue:
+		return item.LinearID
+	case domain.Project:
+		return item.ID
+	case domain.Notification:
+		return item.ID
+	case domain.Cycle:
+		return item.ID
+	}
+	return ""
+}
+
+// itemCount returns the number of loaded items in the current view,
+// including those hidden by the search
+func (m Model) itemCount() int {
+	switch m.viewType {
+	case messages.ProjectView:
+		return len(m.projects)
+	case messages.InboxView:
+		return len(m.notifications)
+	case messages.CycleView:
+		return len(m.cycles)
+	}
+	return len(m.issues)
 }
 
 func (m *Model) Focus() {
 	m.focused = true
+	m.table.Focus()
 }
 
 func (m *Model) Blur() {
 	m.focused = false
+	m.table.Blur()
 }
 
 func (m Model) renderEmptyState() string {
+	if m.err != nil && m.itemCount() == 0 {
+		return m.styles.EmptyState.Render("Could not load this view: " + m.err.Error())
+	}
+	if m.search != "" {
+		return m.styles.EmptyState.Render(fmt.Sprintf("No items match %q", m.search))
+	}
 	return m.styles.EmptyState.Render("No items to display")
 }
 
 func (m *Model) SetSize(width, height int) {
-	m.viewport.Width = width
-	m.viewport.Height = height
+	m.width = width
+	m.height = height
+	m.table.SetColumns(layoutColumns(columnsFor(m.viewType), width))
+	m.table.SetWidth(width)
+	m.table.SetHeight(height)
+	m.scrollToCursor()
 }
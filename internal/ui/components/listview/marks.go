@@ -0,0 +1,158 @@
+package listview
+
+import (
+	"github.com/linear-tui/linear-tui/internal/domain"
+)
+
+// Issues can be marked for a bulk action: space toggles the issue under the
+// cursor, V marks the rows from the issue toggled last to the cursor, and
+// ctrl+a marks every issue matching the filter. Marks are kept by Linear ID
+// across reloads of the view, for as long as the issues stay loaded.
+
+// markable reports whether the rows of the current view can be marked
+func (m Model) markable() bool {
+	return m.viewType.ListsIssues()
+}
+
+// toggleMark marks the issue under the cursor, or unmarks it if it is
+// marked, and makes it the anchor of a range
+func (m *Model) toggleMark() {
+	index, ok := m.selectedIndex()
+	if !ok {
+		return
+	}
+	id := m.issues[index].LinearID
+	if m.marked == nil {
+		m.marked = make(map[string]bool)
+	}
+	if m.marked[id] {
+		delete(m.marked, id)
+	} else {
+		m.marked[id] = true
+	}
+	m.anchor = id
+	m.markingAll = false
+}
+
+// markRange marks the rows from the anchor to the cursor. Without an anchor
+// shown, only the issue under the cursor is marked.
+func (m *Model) markRange() {
+	cursor := m.table.Cursor()
+	if cursor < 0 || cursor >= len(m.visible) {
+		return
+	}
+	from := cursor
+	for i, index := range m.visible {
+		if m.issues[index].LinearID == m.anchor {
+			from = i
+			break
+		}
+	}
+
+	if m.marked == nil {
+		m.marked = make(map[string]bool)
+	}
+	for i := min(from, cursor); i <= max(from, cursor); i++ {
+		m.marked[m.issues[m.visible[i]].LinearID] = true
+	}
+	m.anchor = m.issues[m.visible[cursor]].LinearID
+	m.markingAll = false
+}
+
+// markAll marks every issue matching the filter. The pages not loaded yet
+// are requested and marked as they arrive. If every issue is marked
+// already, they are unmarked instead.
+func (m *Model) markAll() {
+	if m.allMarked() && m.nextCursor == "" {
+		m.ClearMarks()
+		return
+	}
+	m.markVisible()
+	m.markingAll = m.nextCursor != ""
+}
+
+// markVisible marks the rows shown
+func (m *Model) markVisible() {
+	if m.marked == nil {
+		m.marked = make(map[string]bool)
+	}
+	for _, index := range m.visible {
+		m.marked[m.issues[index].LinearID] = true
+	}
+}
+
+// allMarked reports whether every row shown is marked
+func (m Model) allMarked() bool {
+	for _, index := range m.visible {
+		if !m.marked[m.issues[index].LinearID] {
+			return false
+		}
+	}
+	return len(m.visible) > 0
+}
+
+// isMarked reports whether the row at an index of the table rows is marked
+func (m Model) isMarked(row int) bool {
+	return m.markable() && m.marked[m.issues[m.visible[row]].LinearID]
+}
+
+// pruneMarks drops the marks of issues no longer loaded
+func (m *Model) pruneMarks() {
+	if len(m.marked) == 0 {
+		return
+	}
+	loaded := make(map[string]bool, len(m.issues))
+	for _, issue := range m.issues {
+		loaded[issue.LinearID] = true
+	}
+	for id := range m.marked {
+		if !loaded[id] {
+			delete(m.marked, id)
+		}
+	}
+}
+
+// MarkedIssues returns the marked issues, in the order they are loaded
+func (m Model) MarkedIssues() []domain.Issue {
+	if !m.markable() {
+		return nil
+	}
+	var marked []domain.Issue
+	for _, issue := range m.issues {
+		if m.marked[issue.LinearID] {
+			marked = append(marked, issue)
+		}
+	}
+	return marked
+}
+
+// MarkedCount returns the number of marked issues
+func (m Model) MarkedCount() int {
+	if !m.markable() {
+		return 0
+	}
+	return len(m.marked)
+}
+
+// MarkingAll reports whether the issues matching the filter are still being
+// loaded to be marked
+func (m Model) MarkingAll() bool {
+	return m.markingAll
+}
+
+// SetMarked marks exactly the loaded issues with the given Linear IDs
+func (m *Model) SetMarked(ids []string) {
+	m.ClearMarks()
+	m.marked = make(map[string]bool, len(ids))
+	for _, id := range ids {
+		m.marked[id] = true
+	}
+	m.pruneMarks()
+}
+
+// ClearMarks unmarks every issue
+func (m *Model) ClearMarks() {
+	m.marked = nil
+	m.anchor = ""
+	m.markingAll = false
+}
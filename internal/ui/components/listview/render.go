@@ -0,0 +1,199 @@
+package listview
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/table"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/linear-tui/linear-tui/internal/domain"
+	"github.com/mattn/go-runewidth"
+)
+
+// The table is rendered here rather than by the bubbles table, which
+// truncates cells after styling them and so cannot keep highlighted search
+// matches intact. The bubbles table still holds the rows and handles
+// cursor movement.
+
+// headerHeight is the number of lines taken by the column headers and the
+// rule beneath them
+const headerHeight = 2
+
+const ellipsis = "…"
+
+func (m Model) renderTable() string {
+	columns := m.table.Columns()
+	rows := m.table.Rows()
+
+	var body []string
+	height := m.bodyHeight()
+	for i := m.offset; i < len(rows) && len(body) < height; i++ {
+		// A heading is never left at the bottom without its first row
+		if m.startsGroup(i) {
+			if len(body)+2 <= height {
+				body = append(body, m.renderGroup(i))
+			} else if len(body) > 0 {
+				break
+			}
+		}
+
+		var matches cellMatches
+		if i < len(m.highlights) {
+			matches = m.highlights[i]
+		}
+		body = append(body, m.renderRow(columns, i, matches, i == m.table.Cursor(), m.isMarked(i)))
+	}
+
+	lines := append([]string{m.renderHeader(columns)}, body...)
+	return lipgloss.NewStyle().MaxWidth(m.width).Render(strings.Join(lines, "\n"))
+}
+
+func (m Model) renderHeader(columns []table.Column) string {
+	cells := make([]string, len(columns))
+	for i, col := range columns {
+		title := lipgloss.NewStyle().Width(col.Width).MaxWidth(col.Width).Inline(true).
+			Render(runewidth.Truncate(col.Title, col.Width, ellipsis))
+		cells[i] = m.styles.Header.Render(title)
+	}
+	return lipgloss.JoinHorizontal(lipgloss.Top, cells...)
+}
+
+// renderGroup renders the heading of the group of a row
+func (m Model) renderGroup(row int) string {
+	heading := m.styles.Group.Render(m.groupOf(row)) + m.styles.GroupCount.Render(fmt.Sprintf("%d", m.groupSize(row)))
+	return lipgloss.NewStyle().MaxWidth(m.width).Render(heading)
+}
+
+// renderRow renders the row at an index of the table rows. A marked row is
+// tinted, with a bar in place of its left padding.
+func (m Model) renderRow(columns []table.Column, index int, matches cellMatches, selected, marked bool) string {
+	style := m.styles.Cell
+	switch {
+	case selected:
+		style = m.styles.Selected
+	case marked:
+		style = m.styles.Marked
+	}
+	highlight := m.styles.Match.Inherit(style)
+
+	row := m.table.Rows()[index]
+	var b strings.Builder
+	for i, col := range columns {
+		if i == labelsColumn && m.viewType.ListsIssues() {
+			b.WriteString(renderLabels(m.issues[m.visible[index]].Labels, col.Width, style))
+			continue
+		}
+
+		var text string
+		if i < len(row) {
+			text = row[i]
+		}
+		lead := style.Render(" ")
+		if i == 0 && marked {
+			lead = m.styles.Mark.Inherit(style).Render("▌")
+		}
+		b.WriteString(lead + renderCell(text, col.Width, matches[i], style, highlight))
+	}
+	return b.String()
+}
+
+// renderLabels renders labels as chips, each a dot in the label's color
+// followed by its name, within width and padded like other cells. Labels
+// that do not fit are cut short with an ellipsis.
+func renderLabels(labels []domain.Label, width int, style lipgloss.Style) string {
+	var b strings.Builder
+	b.WriteString(style.Render(" "))
+
+	used := 0
+	for i, label := range labels {
+		separator := ""
+		if i > 0 {
+			separator = " "
+		}
+		prefix := runewidth.StringWidth(separator + "● ")
+		if used+prefix >= width {
+			if used+2 <= width {
+				b.WriteString(style.Render(" " + ellipsis))
+				used += 2
+			}
+			break
+		}
+
+		name := runewidth.Truncate(label.Name, width-used-prefix, ellipsis)
+		dot := lipgloss.NewStyle().Foreground(lipgloss.Color(label.Color)).Inherit(style)
+		b.WriteString(style.Render(separator) + dot.Render("●") + style.Render(" "+name))
+		used += prefix + runewidth.StringWidth(name)
+		if name != label.Name {
+			break
+		}
+	}
+
+	b.WriteString(style.Render(strings.Repeat(" ", max(width-used, 0)+1)))
+	return b.String()
+}
+
+// renderCell truncates text to width, pads it with a space on the right
+// and highlights the characters at the matched byte offsets. The caller
+// writes the padding on the left.
+func renderCell(text string, width int, matched []int, style, highlight lipgloss.Style) string {
+	truncated := runewidth.Truncate(text, width, ellipsis)
+
+	// Offsets past the truncation point would land on the ellipsis
+	limit := len(truncated)
+	if truncated != text {
+		limit -= len(ellipsis)
+	}
+	isMatch := make(map[int]bool, len(matched))
+	for _, offset := range matched {
+		if offset < limit {
+			isMatch[offset] = true
+		}
+	}
+
+	var b strings.Builder
+	start, inMatch := 0, false
+	flush := func(end int) {
+		if end == start {
+			return
+		}
+		segmentStyle := style
+		if inMatch {
+			segmentStyle = highlight
+		}
+		b.WriteString(segmentStyle.Render(truncated[start:end]))
+		start = end
+	}
+	for offset := range truncated {
+		if isMatch[offset] != inMatch {
+			flush(offset)
+			inMatch = !inMatch
+		}
+	}
+	flush(len(truncated))
+
+	padding := max(width-runewidth.StringWidth(truncated), 0)
+	b.WriteString(style.Render(strings.Repeat(" ", padding+1)))
+	return b.String()
+}
+
+// bodyHeight is the number of rows that fit below the header
+func (m Model) bodyHeight() int {
+	return max(m.height-headerHeight, 1)
+}
+
+// scrollToCursor moves the visible window of rows so that it contains the
+// cursor
+func (m *Model) scrollToCursor() {
+	cursor, height := m.table.Cursor(), m.bodyHeight()
+	if cursor < m.offset {
+		m.offset = cursor
+	} else if cursor >= m.offset+height {
+		m.offset = cursor - height + 1
+	}
+	m.offset = max(min(m.offset, len(m.table.Rows())-height), 0)
+
+	// Group headings take up lines too
+	for m.offset < cursor && m.linesThrough(m.offset, cursor) > height {
+		m.offset++
+	}
+}
@@ -0,0 +1,173 @@
+package listview
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/linear-tui/linear-tui/internal/ui/messages"
+	"github.com/sahilm/fuzzy"
+)
+
+// cellMatches holds the byte offsets of matched characters in the cells of
+// a row, keyed by column
+type cellMatches map[int][]int
+
+// searchField is a searchable attribute of the listed items
+type searchField struct {
+	values []string
+
+	// column is the column showing the attribute, or -1 if it is not shown
+	column int
+
+	// loose fields hold long text where a scattered match is almost
+	// always noise, so they only match when the matched characters are
+	// close together
+	loose bool
+}
+
+// maxSpread is how far apart, relative to the length of the word, the
+// characters of a match in a loose field may be
+const maxSpread = 3
+
+// loosePenalty is taken off the score of matches in loose fields, so that
+// matches in identifiers and titles rank first
+const loosePenalty = 20
+
+// searchFields returns the searchable attributes of the items in the
+// current view
+func (m Model) searchFields() []searchField {
+	if m.viewType == messages.InboxView {
+		ids := make([]string, len(m.notifications))
+		titles := make([]string, len(m.notifications))
+		reasons := make([]string, len(m.notifications))
+		actors := make([]string, len(m.notifications))
+		for i, notification := range m.notifications {
+			ids[i] = notification.IssueIdentifier
+			titles[i] = notification.IssueTitle
+			reasons[i] = notification.Reason
+			actors[i] = notification.Actor
+		}
+		return []searchField{
+			{values: ids, column: 1},
+			{values: titles, column: 2},
+			{values: reasons, column: 3},
+			{values: actors, column: 4},
+		}
+	}
+
+	if m.viewType == messages.CycleView {
+		names := make([]string, len(m.cycles))
+		teams := make([]string, len(m.cycles))
+		for i, cycle := range m.cycles {
+			names[i] = cycle.Name
+			teams[i] = cycle.Team
+		}
+		return []searchField{
+			{values: names, column: 0},
+			{values: teams, column: 1},
+		}
+	}
+
+	if m.viewType == messages.ProjectView {
+		names := make([]string, len(m.projects))
+		descriptions := make([]string, len(m.projects))
+		for i, project := range m.projects {
+			names[i] = project.Name
+			descriptions[i] = project.Description
+		}
+		return []searchField{
+			{values: names, column: 0},
+			{values: descriptions, column: -1, loose: true},
+		}
+	}
+
+	ids := make([]string, len(m.issues))
+	titles := make([]string, len(m.issues))
+	descriptions := make([]string, len(m.issues))
+	for i, issue := range m.issues {
+		ids[i] = issue.ID
+		titles[i] = issue.Title
+		descriptions[i] = issue.Description
+	}
+	return []searchField{
+		{values: ids, column: 0},
+		{values: titles, column: 1},
+		{values: descriptions, column: -1, loose: true},
+	}
+}
+
+// fuzzyFilter returns the indexes of the items matching every word of
+// search, best match first, along with the matched characters of each.
+// Each word counts its best match among the fields.
+func fuzzyFilter(search string, fields []searchField, count int) ([]int, []cellMatches) {
+	words := strings.Fields(search)
+
+	matched := make([]bool, count)
+	for i := range matched {
+		matched[i] = true
+	}
+	scores := make([]int, count)
+	highlights := make([]cellMatches, count)
+
+	for _, word := range words {
+		best := make(map[int]fuzzy.Match)
+		bestColumn := make(map[int]int)
+		for _, field := range fields {
+			for _, match := range fuzzy.FindNoSort(word, field.values) {
+				if field.loose {
+					if !isCompact(match.MatchedIndexes, len(word)) {
+						continue
+					}
+					match.Score -= loosePenalty
+				}
+				if current, ok := best[match.Index]; ok && current.Score >= match.Score {
+					continue
+				}
+				best[match.Index] = match
+				bestColumn[match.Index] = field.column
+			}
+		}
+
+		for i := range matched {
+			match, ok := best[i]
+			if !ok {
+				matched[i] = false
+				continue
+			}
+			scores[i] += match.Score
+
+			column := bestColumn[i]
+			if column < 0 {
+				continue
+			}
+			if highlights[i] == nil {
+				highlights[i] = make(cellMatches)
+			}
+			highlights[i][column] = append(highlights[i][column], match.MatchedIndexes...)
+		}
+	}
+
+	var indexes []int
+	for i, ok := range matched {
+		if ok {
+			indexes = append(indexes, i)
+		}
+	}
+	sort.SliceStable(indexes, func(a, b int) bool {
+		return scores[indexes[a]] > scores[indexes[b]]
+	})
+
+	result := make([]cellMatches, len(indexes))
+	for i, index := range indexes {
+		result[i] = highlights[index]
+	}
+	return indexes, result
+}
+
+// isCompact reports whether the matched characters lie close together
+func isCompact(indexes []int, wordLen int) bool {
+	if len(indexes) == 0 {
+		return false
+	}
+	return indexes[len(indexes)-1]-indexes[0] < maxSpread*wordLen
+}
@@ -0,0 +1,163 @@
+package listview
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/linear-tui/linear-tui/internal/domain"
+	"github.com/linear-tui/linear-tui/internal/ui/messages"
+)
+
+// The issue list can be shown as a tree, with sub-issues indented under
+// their parent issue. Sub-issues whose parent is not loaded are shown at the
+// top level. A search flattens the tree, so that matches are ranked.
+
+// treed reports whether the rows of the current view are shown as a tree
+func (m Model) treed() bool {
+	return m.tree && m.viewType == messages.IssueView && m.search == ""
+}
+
+// arrangeTree orders the visible rows as a tree, leaving out the
+// descendants of collapsed issues, and records the depth of each row
+func (m *Model) arrangeTree() {
+	index := make(map[string]int, len(m.issues))
+	for i, issue := range m.issues {
+		index[issue.LinearID] = i
+	}
+
+	var roots []int
+	children := make(map[int][]int)
+	for i, issue := range m.issues {
+		if parent, ok := index[issue.ParentID]; ok && parent != i {
+			children[parent] = append(children[parent], i)
+		} else {
+			roots = append(roots, i)
+		}
+	}
+
+	visible := make([]int, 0, len(m.issues))
+	depths := make([]int, 0, len(m.issues))
+	seen := make(map[int]bool, len(m.issues))
+	var walk func(i, depth int)
+	walk = func(i, depth int) {
+		if seen[i] {
+			return
+		}
+		seen[i] = true
+		visible = append(visible, i)
+		depths = append(depths, depth)
+		if !m.collapsed[m.issues[i].LinearID] {
+			for _, child := range children[i] {
+				walk(child, depth+1)
+			}
+		}
+	}
+	for _, root := range roots {
+		walk(root, 0)
+	}
+
+	m.visible, m.depths, m.highlights = visible, depths, nil
+	m.children = children
+}
+
+// treeTitle returns the title of the issue at a row of the tree, indented
+// by its depth. Issues with sub-issues are marked as expanded or collapsed
+// and end with how many of their loaded sub-issues are done.
+func (m Model) treeTitle(row int) string {
+	index := m.visible[row]
+	issue := m.issues[index]
+	indent := strings.Repeat("  ", m.depths[row])
+
+	children := m.children[index]
+	if len(children) == 0 {
+		return indent + "  " + issue.Title
+	}
+
+	done := 0
+	for _, child := range children {
+		if closed(m.issues[child]) {
+			done++
+		}
+	}
+	marker := "▾ "
+	if m.collapsed[issue.LinearID] {
+		marker = "▸ "
+	}
+	return fmt.Sprintf("%s%s%s  %d/%d", indent, marker, issue.Title, done, len(children))
+}
+
+// closed reports whether an issue is done or canceled
+func closed(issue domain.Issue) bool {
+	return issue.StateType == "completed" || issue.StateType == "canceled"
+}
+
+// toggleTree switches between the tree and the flat list, keeping the
+// selected issue selected
+func (m *Model) toggleTree() {
+	m.tree = !m.tree
+	m.reselect(m.refresh)
+}
+
+// setCollapsed collapses or expands the sub-issues of the selected issue.
+// Collapsing an issue without sub-issues, or one already collapsed,
+// collapses its parent instead and selects it.
+func (m *Model) setCollapsed(collapsed bool) {
+	index, ok := m.selectedIndex()
+	if !ok || !m.treed() {
+		return
+	}
+
+	if collapsed && (len(m.children[index]) == 0 || m.collapsed[m.issues[index].LinearID]) {
+		parent, ok := m.parentIndex(index)
+		if !ok {
+			return
+		}
+		index = parent
+	}
+	if len(m.children[index]) == 0 {
+		return
+	}
+
+	key := m.issues[index].LinearID
+	if m.collapsed == nil {
+		m.collapsed = make(map[string]bool)
+	}
+	m.collapsed[key] = collapsed
+	m.refresh()
+	m.selectKey(key)
+}
+
+// parentIndex returns the index of the loaded parent of an issue
+func (m Model) parentIndex(index int) (int, bool) {
+	parentID := m.issues[index].ParentID
+	for i, issue := range m.issues {
+		if parentID != "" && issue.LinearID == parentID {
+			return i, true
+		}
+	}
+	return 0, false
+}
+
+// reselect runs change, then moves the cursor back to the item that was
+// selected before it
+func (m *Model) reselect(change func()) {
+	var key string
+	if item := m.SelectedItem(); item != nil {
+		key = itemKey(item)
+	}
+	change()
+	m.selectKey(key)
+}
+
+// selectKey moves the cursor to the row of the item with a key, if it is
+// shown
+func (m *Model) selectKey(key string) {
+	items := m.items()
+	for row, index := range m.visible {
+		if itemKey(items[index]) == key {
+			m.table.SetCursor(row)
+			break
+		}
+	}
+	m.scrollToCursor()
+}
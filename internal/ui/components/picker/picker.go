@@ -0,0 +1,340 @@
+package picker
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/linear-tui/linear-tui/internal/ui/messages"
+	"github.com/sahilm/fuzzy"
+)
+
+// Option is a value that can be picked
+type Option struct {
+	ID    string
+	Label string
+
+	// Detail is shown dimmed after the label
+	Detail string
+
+	// Color is shown as a swatch before the label, if set
+	Color string
+}
+
+type Styles struct {
+	Box      lipgloss.Style
+	Title    lipgloss.Style
+	Item     lipgloss.Style
+	Selected lipgloss.Style
+	Match    lipgloss.Style
+	Detail   lipgloss.Style
+	Hint     lipgloss.Style
+	Empty    lipgloss.Style
+}
+
+// chromeHeight is the number of lines around the options: the border, the
+// title, the search input and the hint
+const chromeHeight = 5
+
+// Model is a searchable list of options. Typing narrows the options by
+// fuzzy matching their labels. In multi-select mode tab toggles options and
+// enter confirms the selection; otherwise enter picks the highlighted one.
+// Either way the result is sent in a PickerClosedMsg.
+type Model struct {
+	id      string
+	title   string
+	options []Option
+	multi   bool
+
+	// selected holds the IDs of the options toggled in multi-select mode
+	selected map[string]bool
+
+	input   textinput.Model
+	visible []int
+	matches [][]int
+	cursor  int
+	offset  int
+
+	width  int
+	height int
+	styles Styles
+}
+
+// New creates a picker. id is sent back in the PickerClosedMsg.
+func New(id, title string, options []Option, multi bool) Model {
+	styles := defaultStyles()
+
+	input := textinput.New()
+	input.Prompt = "> "
+	input.PromptStyle = styles.Title
+	input.Placeholder = "Type to search"
+
+	m := Model{
+		id:       id,
+		title:    title,
+		options:  options,
+		multi:    multi,
+		selected: make(map[string]bool),
+		input:    input,
+		width:    50,
+		height:   16,
+		styles:   styles,
+	}
+	m.filter()
+	return m
+}
+
+func defaultStyles() Styles {
+	return Styles{
+		Box: lipgloss.NewStyle().
+			BorderStyle(lipgloss.RoundedBorder()).
+			BorderForeground(lipgloss.Color("#874BFD")).
+			Padding(0, 1),
+		Title: lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#874BFD")).
+			Bold(true),
+		Item: lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#FAFAFA")),
+		Selected: lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#FAFAFA")).
+			Background(lipgloss.Color("#874BFD")).
+			Bold(true),
+		Match: lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#F5C542")).
+			Underline(true),
+		Detail: lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#626262")),
+		Hint: lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#626262")).
+			Italic(true),
+		Empty: lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#626262")).
+			Italic(true),
+	}
+}
+
+// Select marks options as selected. In single-select mode the cursor moves
+// to the first of them.
+func (m *Model) Select(ids ...string) {
+	for _, id := range ids {
+		if m.multi {
+			m.selected[id] = true
+			continue
+		}
+		for i, index := range m.visible {
+			if m.options[index].ID == id {
+				m.cursor = i
+				m.scrollToCursor()
+				return
+			}
+		}
+	}
+}
+
+// Focus starts taking input
+func (m *Model) Focus() tea.Cmd {
+	return m.input.Focus()
+}
+
+func (m Model) Init() tea.Cmd {
+	return nil
+}
+
+func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		var cmd tea.Cmd
+		m.input, cmd = m.input.Update(msg)
+		return m, cmd
+	}
+
+	switch keyMsg.String() {
+	case "esc":
+		return m, m.close(nil, true)
+	case "enter":
+		return m, m.confirm()
+	case "up", "ctrl+p", "ctrl+k":
+		m.moveCursor(-1)
+		return m, nil
+	case "down", "ctrl+n", "ctrl+j":
+		m.moveCursor(1)
+		return m, nil
+	case "pgup":
+		m.moveCursor(-m.listHeight())
+		return m, nil
+	case "pgdown":
+		m.moveCursor(m.listHeight())
+		return m, nil
+	case "tab":
+		if m.multi {
+			if option, ok := m.highlighted(); ok {
+				m.selected[option.ID] = !m.selected[option.ID]
+			}
+			m.moveCursor(1)
+			return m, nil
+		}
+	}
+
+	previous := m.input.Value()
+	var cmd tea.Cmd
+	m.input, cmd = m.input.Update(msg)
+	if m.input.Value() != previous {
+		m.filter()
+	}
+	return m, cmd
+}
+
+// confirm closes the picker with the chosen options
+func (m Model) confirm() tea.Cmd {
+	if !m.multi {
+		option, ok := m.highlighted()
+		if !ok {
+			return nil
+		}
+		return m.close([]string{option.ID}, false)
+	}
+
+	selected := []string{}
+	for _, option := range m.options {
+		if m.selected[option.ID] {
+			selected = append(selected, option.ID)
+		}
+	}
+	return m.close(selected, false)
+}
+
+func (m Model) close(selected []string, canceled bool) tea.Cmd {
+	id := m.id
+	return func() tea.Msg {
+		return messages.PickerClosedMsg{ID: id, Selected: selected, Canceled: canceled}
+	}
+}
+
+func (m Model) highlighted() (Option, bool) {
+	if m.cursor < 0 || m.cursor >= len(m.visible) {
+		return Option{}, false
+	}
+	return m.options[m.visible[m.cursor]], true
+}
+
+// filter narrows the options to those matching the search, best first
+func (m *Model) filter() {
+	search := strings.TrimSpace(m.input.Value())
+	m.visible = m.visible[:0]
+	m.matches = m.matches[:0]
+
+	if search == "" {
+		for i := range m.options {
+			m.visible = append(m.visible, i)
+			m.matches = append(m.matches, nil)
+		}
+	} else {
+		labels := make([]string, len(m.options))
+		for i, option := range m.options {
+			labels[i] = option.Label
+		}
+		for _, match := range fuzzy.Find(search, labels) {
+			m.visible = append(m.visible, match.Index)
+			m.matches = append(m.matches, match.MatchedIndexes)
+		}
+	}
+
+	m.cursor = 0
+	m.offset = 0
+}
+
+func (m *Model) moveCursor(delta int) {
+	m.cursor = max(min(m.cursor+delta, len(m.visible)-1), 0)
+	m.scrollToCursor()
+}
+
+func (m *Model) scrollToCursor() {
+	height := m.listHeight()
+	if m.cursor < m.offset {
+		m.offset = m.cursor
+	} else if m.cursor >= m.offset+height {
+		m.offset = m.cursor - height + 1
+	}
+}
+
+func (m Model) listHeight() int {
+	return max(m.height-chromeHeight, 1)
+}
+
+func (m Model) View() string {
+	innerWidth := max(m.width-m.styles.Box.GetHorizontalFrameSize(), 1)
+
+	lines := []string{m.styles.Title.Render(m.title), m.input.View()}
+
+	if len(m.visible) == 0 {
+		lines = append(lines, m.styles.Empty.Render("No matches"))
+	}
+	end := min(m.offset+m.listHeight(), len(m.visible))
+	for i := m.offset; i < end; i++ {
+		lines = append(lines, m.renderOption(m.options[m.visible[i]], m.matches[i], i == m.cursor, innerWidth))
+	}
+
+	hint := "enter: select | esc: cancel"
+	if m.multi {
+		hint = "tab: toggle | enter: confirm | esc: cancel"
+	}
+	lines = append(lines, m.styles.Hint.Render(hint))
+
+	return m.styles.Box.Width(max(m.width-m.styles.Box.GetHorizontalBorderSize(), 1)).Render(strings.Join(lines, "\n"))
+}
+
+func (m Model) renderOption(option Option, matched []int, highlighted bool, width int) string {
+	style := m.styles.Item
+	if highlighted {
+		style = m.styles.Selected
+	}
+	matchStyle := m.styles.Match.Inherit(style)
+
+	var b strings.Builder
+	if m.multi {
+		check := "[ ] "
+		if m.selected[option.ID] {
+			check = "[x] "
+		}
+		b.WriteString(style.Render(check))
+	}
+	if option.Color != "" {
+		b.WriteString(lipgloss.NewStyle().Foreground(lipgloss.Color(option.Color)).Inherit(style).Render("● "))
+	}
+
+	isMatch := make(map[int]bool, len(matched))
+	for _, offset := range matched {
+		isMatch[offset] = true
+	}
+	for offset, r := range option.Label {
+		if isMatch[offset] {
+			b.WriteString(matchStyle.Render(string(r)))
+		} else {
+			b.WriteString(style.Render(string(r)))
+		}
+	}
+
+	if option.Detail != "" {
+		b.WriteString(m.styles.Detail.Inherit(style).Render("  " + option.Detail))
+	}
+
+	line := b.String()
+	if padding := width - lipgloss.Width(line); padding > 0 {
+		line += style.Render(strings.Repeat(" ", padding))
+	}
+	return lipgloss.NewStyle().MaxWidth(width).Render(line)
+}
+
+// SetSize sets the size of the picker, including its border
+func (m *Model) SetSize(width, height int) {
+	m.width = width
+	m.height = height
+	m.input.Width = max(width-m.styles.Box.GetHorizontalFrameSize()-lipgloss.Width(m.input.Prompt)-1, 1)
+	m.scrollToCursor()
+}
+
+// ID returns the identifier sent back when the picker closes
+func (m Model) ID() string {
+	return m.id
+}
@@ -0,0 +1,253 @@
+// Package projectview renders the header of an open project: its people,
+// dates, milestones and health, and a bar of its issues by workflow state.
+// The project's issues are listed beneath it in the normal issue table.
+package projectview
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/linear-tui/linear-tui/internal/domain"
+)
+
+type Styles struct {
+	Title     lipgloss.Style
+	Label     lipgloss.Style
+	Value     lipgloss.Style
+	Meta      lipgloss.Style
+	OnTrack   lipgloss.Style
+	AtRisk    lipgloss.Style
+	OffTrack  lipgloss.Style
+	Rule      lipgloss.Style
+	Error     lipgloss.Style
+	Container lipgloss.Style
+}
+
+// labelWidth is the width of the field labels
+const labelWidth = 12
+
+// dateFormat is how dates are shown
+const dateFormat = "Jan 02 2006"
+
+type Model struct {
+	project domain.Project
+	details *domain.ProjectDetails
+	err     error
+	width   int
+	styles  Styles
+}
+
+func New() Model {
+	return Model{
+		width:  80,
+		styles: defaultStyles(),
+	}
+}
+
+func defaultStyles() Styles {
+	return Styles{
+		Title: lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#FAFAFA")).
+			Bold(true),
+		Label: lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#626262")).
+			Width(labelWidth),
+		Value: lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#FAFAFA")),
+		Meta: lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#626262")),
+		OnTrack: lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#4CB782")).
+			Bold(true),
+		AtRisk: lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#F2C94C")).
+			Bold(true),
+		OffTrack: lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#EB5757")).
+			Bold(true),
+		Rule: lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#626262")),
+		Error: lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#FF5F87")),
+		Container: lipgloss.NewStyle().
+			Padding(0, 1),
+	}
+}
+
+// SetProject shows a project. Its details are expected to follow in
+// SetDetails.
+func (m *Model) SetProject(project domain.Project) {
+	m.project = project
+	m.details = nil
+	m.err = nil
+}
+
+// SetDetails shows the details of the project shown, or the error
+// fetching them. Details of another project are ignored.
+func (m *Model) SetDetails(details *domain.ProjectDetails, err error) {
+	if details != nil && details.ID != m.project.ID {
+		return
+	}
+	m.details = details
+	m.err = err
+}
+
+func (m *Model) SetWidth(width int) {
+	m.width = width
+}
+
+// Height returns the number of lines the header takes
+func (m Model) Height() int {
+	return lipgloss.Height(m.View())
+}
+
+func (m Model) View() string {
+	width := m.contentWidth()
+	title := m.styles.Title.Render(m.project.Name)
+	lines := []string{title}
+
+	switch {
+	case m.err != nil:
+		lines = append(lines, m.styles.Error.Width(width).Render("Could not load project: "+m.err.Error()))
+	case m.details == nil:
+		lines = append(lines, m.styles.Meta.Render("Loading project..."))
+	default:
+		details := m.details
+		if health := m.renderHealth(details.Health); health != "" {
+			lines[0] = title + "  " + health
+		}
+		lines = append(lines,
+			m.renderField("Status", fmt.Sprintf("%s · %.0f%% done", details.Status, details.Progress*100)),
+			m.renderField("Lead", details.Lead),
+			m.renderField("Members", strings.Join(details.Members, ", ")),
+			m.renderField("Dates", formatDates(details.StartDate, details.TargetDate)),
+			m.renderField("Milestones", m.renderMilestones(details.Milestones)),
+			m.renderField("Issues", m.renderBar(details.States, width-labelWidth)),
+		)
+		if legend := m.renderLegend(details.States); legend != "" {
+			lines = append(lines, strings.Repeat(" ", labelWidth)+legend)
+		}
+	}
+
+	lines = append(lines, m.styles.Rule.Render(strings.Repeat("─", width)))
+	return m.styles.Container.Render(lipgloss.JoinVertical(lipgloss.Left, lines...))
+}
+
+// renderField renders a line of the header, with a dash for empty values
+func (m Model) renderField(label, value string) string {
+	if value == "" {
+		value = "-"
+	}
+	valueWidth := max(m.contentWidth()-labelWidth, 1)
+	return lipgloss.JoinHorizontal(lipgloss.Top,
+		m.styles.Label.Render(label),
+		m.styles.Value.Width(valueWidth).Render(value),
+	)
+}
+
+// renderHealth renders the health of the project in its color
+func (m Model) renderHealth(health string) string {
+	switch health {
+	case "On track":
+		return m.styles.OnTrack.Render("● " + health)
+	case "At risk":
+		return m.styles.AtRisk.Render("● " + health)
+	case "Off track":
+		return m.styles.OffTrack.Render("● " + health)
+	}
+	return ""
+}
+
+// renderMilestones lists the milestones with their target dates
+func (m Model) renderMilestones(milestones []domain.Milestone) string {
+	parts := make([]string, len(milestones))
+	for i, milestone := range milestones {
+		parts[i] = "◆ " + milestone.Name
+		if !milestone.TargetDate.IsZero() {
+			parts[i] += " (" + milestone.TargetDate.Format("Jan 02") + ")"
+		}
+	}
+	return strings.Join(parts, "  ")
+}
+
+// renderBar renders the issues by state as a bar of the given width, each
+// state taking a share of it in the state's color. States with any issues
+// take at least one cell.
+func (m Model) renderBar(states []domain.StateCount, width int) string {
+	total := 0
+	for _, state := range states {
+		total += state.Count
+	}
+	if total == 0 || width <= 0 {
+		return m.styles.Meta.Render("No issues")
+	}
+
+	widths := barWidths(states, total, width)
+	var b strings.Builder
+	for i, state := range states {
+		b.WriteString(lipgloss.NewStyle().Foreground(lipgloss.Color(state.Color)).Render(strings.Repeat("█", widths[i])))
+	}
+	return b.String()
+}
+
+// barWidths shares width out among the states in proportion to their
+// counts, giving the cells lost to rounding to the largest remainders
+func barWidths(states []domain.StateCount, total, width int) []int {
+	widths := make([]int, len(states))
+	remainders := make([]int, len(states))
+	used := 0
+	for i, state := range states {
+		if state.Count == 0 {
+			continue
+		}
+		widths[i] = max(state.Count*width/total, 1)
+		remainders[i] = state.Count * width % total
+		used += widths[i]
+	}
+	for used < width {
+		best := -1
+		for i := range states {
+			if states[i].Count > 0 && (best == -1 || remainders[i] > remainders[best]) {
+				best = i
+			}
+		}
+		widths[best]++
+		remainders[best] = -1
+		used++
+	}
+	return widths
+}
+
+// renderLegend names the states of the bar with their counts
+func (m Model) renderLegend(states []domain.StateCount) string {
+	var parts []string
+	for _, state := range states {
+		if state.Count == 0 {
+			continue
+		}
+		swatch := lipgloss.NewStyle().Foreground(lipgloss.Color(state.Color)).Render("■")
+		parts = append(parts, swatch+m.styles.Meta.Render(fmt.Sprintf(" %s %d", state.Name, state.Count)))
+	}
+	return lipgloss.NewStyle().Width(max(m.contentWidth()-labelWidth, 1)).Render(strings.Join(parts, "  "))
+}
+
+// formatDates formats the start and target dates of a project, either of
+// which may be unset
+func formatDates(start, target time.Time) string {
+	switch {
+	case start.IsZero() && target.IsZero():
+		return ""
+	case start.IsZero():
+		return "→ " + target.Format(dateFormat)
+	case target.IsZero():
+		return start.Format(dateFormat) + " →"
+	}
+	return start.Format(dateFormat) + " → " + target.Format(dateFormat)
+}
+
+// contentWidth is the width available inside the padding
+func (m Model) contentWidth() int {
+	return max(m.width-m.styles.Container.GetHorizontalFrameSize(), 1)
+}
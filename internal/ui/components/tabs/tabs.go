@@ -9,6 +9,7 @@ import (
 type Styles struct {
 	TabActive   lipgloss.Style
 	TabInactive lipgloss.Style
+	Label       lipgloss.Style
 }
 
 type Model struct {
@@ -16,6 +17,9 @@ type Model struct {
 	active  int
 	focused bool
 	styles  Styles
+
+	// label is shown after the tabs, naming what the tabs show
+	label string
 }
 
 func (m Model) Init() tea.Cmd {
@@ -66,6 +70,9 @@ func defaultStyles() Styles {
 		TabInactive: lipgloss.NewStyle().
 			Foreground(lipgloss.Color("#626262")).
 			Padding(0, 2),
+		Label: lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#874BFD")).
+			Padding(0, 2),
 	}
 }
 
@@ -78,9 +85,22 @@ func (m Model) View() string {
 			tabs = append(tabs, m.styles.TabInactive.Render(tab))
 		}
 	}
+	if m.label != "" {
+		tabs = append(tabs, m.styles.Label.Render(m.label))
+	}
 	return lipgloss.JoinHorizontal(lipgloss.Top, tabs...)
 }
 
+// SetActive selects a tab, as when switching to it from another view
+func (m *Model) SetActive(index int) {
+	m.active = index
+}
+
+// SetLabel sets the text shown after the tabs
+func (m *Model) SetLabel(label string) {
+	m.label = label
+}
+
 func (m *Model) Focus() {
 	m.focused = true
 }
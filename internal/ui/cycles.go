@@ -0,0 +1,42 @@
+package ui
+
+import (
+	"fmt"
+	"slices"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/linear-tui/linear-tui/internal/domain"
+	"github.com/linear-tui/linear-tui/internal/query"
+	"github.com/linear-tui/linear-tui/internal/ui/messages"
+)
+
+// openCycle switches to the issue list filtered to a cycle. The current
+// cycle is filtered as the active one, so that the filter keeps following
+// it across every team shown. Other cycles are filtered by team and
+// number, since numbers are counted per team, and in every state, so that
+// a past cycle shows the issues completed in it.
+func (m *Model) openCycle(cycle domain.Cycle) tea.Cmd {
+	text := "cycle:active"
+	if cycle.Status != "Current" {
+		team := cycle.TeamID
+		for _, t := range m.service.GetTeams() {
+			if t.ID == cycle.TeamID {
+				team = t.Key
+				break
+			}
+		}
+		text = fmt.Sprintf("team:%s cycle:%d state:triage,open,closed", team, cycle.Number)
+	}
+	q, err := query.Parse(text)
+	if err != nil {
+		return nil
+	}
+
+	m.filters[messages.IssueView] = text
+	m.filterBar.SetApplied(text)
+	cmd := m.applyQuery(q)
+
+	index := slices.Index(tabViews, messages.IssueView)
+	m.tabs.SetActive(index)
+	return tea.Batch(cmd, func() tea.Msg { return messages.TabSwitchedMsg{Index: index} })
+}
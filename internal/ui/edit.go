@@ -0,0 +1,380 @@
+package ui
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/linear-tui/linear-tui/internal/domain"
+	"github.com/linear-tui/linear-tui/internal/ui/components/picker"
+	"github.com/linear-tui/linear-tui/internal/ui/editor"
+	"github.com/linear-tui/linear-tui/internal/ui/messages"
+)
+
+// editPickerID identifies the picker of an edit in PickerClosedMsg
+const editPickerID = "edit"
+
+// editEditorID identifies the title and description of an edit in
+// EditorFinishedMsg
+const editEditorID = "issue"
+
+// editField is a field of an issue that can be edited in place
+type editField int
+
+const (
+	editStatus editField = iota
+	editPriority
+	editAssignee
+	editProject
+	editLabels
+	editCycle
+	editText
+)
+
+// editKeys maps the keys that edit the selected issue to the field edited
+var editKeys = map[string]editField{
+	"s": editStatus,
+	"p": editPriority,
+	"a": editAssignee,
+	"P": editProject,
+	"l": editLabels,
+	"C": editCycle,
+	"e": editText,
+}
+
+var editTitles = map[editField]string{
+	editStatus:   "Status",
+	editPriority: "Priority",
+	editAssignee: "Assignee",
+	editProject:  "Project",
+	editLabels:   "Labels",
+	editCycle:    "Cycle",
+}
+
+// issueEdit is an edit of a field of an issue. The status, labels and cycle
+// belong to the issue's team, so they are loaded before the picker opens.
+type issueEdit struct {
+	issue   domain.Issue
+	field   editField
+	loading bool
+	picker  *picker.Model
+
+	// bulk holds the issues of a bulk edit, in which case issue is the
+	// first of them
+	bulk []domain.Issue
+}
+
+// selectedIssue returns the issue the edit keys act on: the one under the
+// cursor in an issue list or on the board, or the one in the detail pane
+func (m Model) selectedIssue() (domain.Issue, bool) {
+	var item interface{}
+	switch {
+	case m.focusArea == FocusMain && m.currentView.ListsIssues():
+		item = m.listView.SelectedItem()
+	case m.focusArea == FocusMain && m.currentView == messages.BoardView:
+		if issue, ok := m.board.SelectedIssue(); ok {
+			item = issue
+		}
+	case m.focusArea == FocusDetailPane:
+		item = m.detailPane.Item()
+	}
+	issue, ok := item.(domain.Issue)
+	return issue, ok
+}
+
+// startEdit starts editing a field of an issue
+func (m *Model) startEdit(issue domain.Issue, field editField) tea.Cmd {
+	return m.beginEdit(&issueEdit{issue: issue, field: field})
+}
+
+// beginEdit starts an edit, loading the options of the issue's team first
+// where the field needs them
+func (m *Model) beginEdit(edit *issueEdit) tea.Cmd {
+	m.edit = edit
+	issue, field := edit.issue, edit.field
+
+	switch field {
+	case editText:
+		return editor.Open(editEditorID, issue.Title+"\n\n"+issue.Description)
+	case editStatus, editLabels, editCycle:
+		if issue.TeamID == "" {
+			m.edit = nil
+			m.footer.SetError(fmt.Sprintf("%s has no team to load options from", issue.ID))
+			return nil
+		}
+		m.edit.loading = true
+		if field == editCycle {
+			return loadTeamCyclesCmd(m.service, issue.TeamID)
+		}
+		return loadTeamOptionsCmd(m.service, issue.TeamID)
+	case editPriority:
+		return m.openEditPicker(priorityOptions())
+	case editAssignee:
+		// An empty ID clears the field
+		return m.openEditPicker(append([]picker.Option{{Label: "Unassigned"}}, userOptions(m.service.GetUsers())...))
+	case editProject:
+		return m.openEditPicker(append([]picker.Option{{Label: "No project"}}, projectOptions(m.projects)...))
+	}
+	return nil
+}
+
+// editOptionsLoaded opens the picker of a status or labels edit once the
+// options of the issue's team have loaded
+func (m *Model) editOptionsLoaded(msg teamOptionsLoadedMsg) tea.Cmd {
+	if m.edit == nil || !m.edit.loading || m.edit.field == editCycle || m.edit.issue.TeamID != msg.teamID {
+		return nil
+	}
+	if msg.err != nil {
+		m.edit = nil
+		m.footer.SetError("Could not load options: " + msg.err.Error())
+		return nil
+	}
+
+	m.edit.loading = false
+	if m.edit.field == editStatus {
+		return m.openEditPicker(stateOptions(msg.states))
+	}
+	return m.openEditPicker(labelOptions(msg.labels))
+}
+
+// editCyclesLoaded opens the picker of a cycle edit once the cycles of the
+// issue's team have loaded. Issues can only be moved into cycles that have
+// not ended.
+func (m *Model) editCyclesLoaded(msg cyclesLoadedMsg) tea.Cmd {
+	if m.edit == nil || !m.edit.loading || m.edit.field != editCycle || m.edit.issue.TeamID != msg.teamID {
+		return nil
+	}
+	if msg.err != nil {
+		m.edit = nil
+		m.footer.SetError("Could not load cycles: " + msg.err.Error())
+		return nil
+	}
+
+	m.edit.loading = false
+	return m.openEditPicker(append([]picker.Option{{Label: "No cycle"}}, cycleOptions(msg.cycles)...))
+}
+
+// openEditPicker opens the picker for the field being edited, with its
+// current value selected
+func (m *Model) openEditPicker(options []picker.Option) tea.Cmd {
+	issue, field := m.edit.issue, m.edit.field
+	title := fmt.Sprintf("%s · %s", editTitles[field], issue.ID)
+	if len(m.edit.bulk) > 0 {
+		title = editTitles[field] + " · " + pluralIssues(len(m.edit.bulk))
+	}
+
+	// A bulk edit starts from the values all its issues share
+	current := editValues(issue, field)
+	for _, other := range m.edit.bulk {
+		others := editValues(other, field)
+		current = slices.DeleteFunc(current, func(value string) bool {
+			return !slices.Contains(others, value)
+		})
+	}
+
+	p := picker.New(editPickerID, title, options, field == editLabels)
+	p.Select(current...)
+	p.SetSize(m.pickerSize())
+
+	m.edit.picker = &p
+	return p.Focus()
+}
+
+// editValues returns the IDs of the current value of a field of an issue:
+// one for most fields, any number for the labels
+func editValues(issue domain.Issue, field editField) []string {
+	switch field {
+	case editStatus:
+		return []string{issue.StateID}
+	case editPriority:
+		return []string{strconv.Itoa(issue.PriorityNumber)}
+	case editAssignee:
+		return []string{issue.AssigneeID}
+	case editProject:
+		return []string{issue.ProjectID}
+	case editCycle:
+		return []string{issue.CycleID}
+	case editLabels:
+		return labelIDs(issue.Labels)
+	}
+	return nil
+}
+
+// pickerSize returns the size of the picker of an edit, which is shown in
+// the middle of the screen
+func (m Model) pickerSize() (int, int) {
+	return min(60, m.width-4), min(20, m.height-2)
+}
+
+// updateEdit handles a key press while an edit is in progress
+func (m Model) updateEdit(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if m.edit.picker == nil {
+		// Only waiting for options or the editor, which esc gives up on
+		if msg.String() == "esc" && m.edit.loading {
+			m.edit = nil
+		}
+		return m, nil
+	}
+
+	var cmd tea.Cmd
+	*m.edit.picker, cmd = m.edit.picker.Update(msg)
+	return m, cmd
+}
+
+// finishEdit sends the value picked for a field, if it changed
+func (m *Model) finishEdit(msg messages.PickerClosedMsg) tea.Cmd {
+	if m.edit == nil || msg.ID != editPickerID {
+		return nil
+	}
+	edit := *m.edit
+	m.edit = nil
+	if msg.Canceled {
+		return nil
+	}
+	if len(edit.bulk) > 0 {
+		return m.finishBulkEdit(edit, msg.Selected)
+	}
+
+	update, changed := editUpdate(edit.issue, edit.field, msg.Selected)
+	if !changed {
+		return nil
+	}
+	return m.updateIssue(edit.issue, update)
+}
+
+// editUpdate returns the update that sets a field of an issue to the IDs
+// picked. changed is false if the field has that value already.
+func editUpdate(issue domain.Issue, field editField, selected []string) (update domain.IssueUpdate, changed bool) {
+	var value string
+	if len(selected) > 0 {
+		value = selected[0]
+	}
+
+	switch field {
+	case editStatus:
+		if value == issue.StateID {
+			return update, false
+		}
+		update.StateID = &value
+	case editPriority:
+		priority, err := strconv.Atoi(value)
+		if err != nil || priority == issue.PriorityNumber {
+			return update, false
+		}
+		update.Priority = &priority
+	case editAssignee:
+		if value == issue.AssigneeID {
+			return update, false
+		}
+		update.AssigneeID = &value
+	case editProject:
+		if value == issue.ProjectID {
+			return update, false
+		}
+		update.ProjectID = &value
+	case editCycle:
+		if value == issue.CycleID {
+			return update, false
+		}
+		update.CycleID = &value
+	case editLabels:
+		current := labelIDs(issue.Labels)
+		slices.Sort(current)
+		if slices.Equal(current, slices.Sorted(slices.Values(selected))) {
+			return update, false
+		}
+		update.LabelIDs = selected
+	}
+	return update, true
+}
+
+// finishTextEdit sends the title and description written in the editor, if
+// they changed. The first line is the title and the rest the description.
+func (m *Model) finishTextEdit(msg messages.EditorFinishedMsg) tea.Cmd {
+	if m.edit == nil || m.edit.field != editText || msg.ID != editEditorID {
+		return nil
+	}
+	issue := m.edit.issue
+	m.edit = nil
+	if msg.Err != nil {
+		m.footer.SetError("Could not open the editor: " + msg.Err.Error())
+		return nil
+	}
+
+	title, description, _ := strings.Cut(msg.Text, "\n")
+	title = strings.TrimSpace(title)
+	description = strings.TrimSpace(description)
+	if title == "" {
+		m.footer.SetError("The title cannot be empty")
+		return nil
+	}
+
+	var update domain.IssueUpdate
+	if title != issue.Title {
+		update.Title = &title
+	}
+	if description != strings.TrimSpace(issue.Description) {
+		update.Description = &description
+	}
+	if update.Title == nil && update.Description == nil {
+		return nil
+	}
+	return m.updateIssue(issue, update)
+}
+
+// updateIssue sends an update of an issue, showing its new status,
+// priority and assignee straight away rather than once Linear confirms
+// them
+func (m *Model) updateIssue(issue domain.Issue, update domain.IssueUpdate) tea.Cmd {
+	shown, seq := m.service.BeginUpdate(issue, update)
+	m.showIssue(shown)
+	m.recordUpdate(seq, issue, update)
+	return updateIssueCmd(m.service, issue.LinearID, seq, update)
+}
+
+// issueUpdated shows an issue once an update has finished. A failed update
+// is reverted, keeping any other update of the issue still in flight, and
+// a toast says why it failed.
+func (m *Model) issueUpdated(msg messages.IssueUpdatedMsg) tea.Cmd {
+	shown, ok := m.service.FinishUpdate(msg.IssueID, msg.Seq, msg.Issue)
+	if !ok {
+		m.footer.SetError("Could not update issue: " + msg.Err.Error())
+		return m.updateFinished(msg, shown)
+	}
+	m.showIssue(shown)
+	var cmd tea.Cmd
+	if msg.Err != nil {
+		cmd = m.footer.Toast(fmt.Sprintf("Could not update %s, change reverted: %s", shown.ID, explainError(msg.Err)))
+	}
+	if undoCmd := m.updateFinished(msg, shown); undoCmd != nil {
+		return undoCmd
+	}
+	return cmd
+}
+
+// showIssue shows an issue in place of the old copy in the lists, on the
+// board and in the detail pane
+func (m *Model) showIssue(issue domain.Issue) {
+	for _, issues := range [][]domain.Issue{m.issues, m.myIssues, m.createdIssues, m.projectIssues} {
+		for i := range issues {
+			if issues[i].LinearID == issue.LinearID {
+				issues[i] = issue
+			}
+		}
+	}
+	if m.currentView.ListsIssues() {
+		m.listView.UpdateItem(issue)
+	}
+	m.board.UpdateIssue(issue)
+	m.detailPane.UpdateIssue(issue)
+}
+
+func labelIDs(labels []domain.Label) []string {
+	ids := make([]string, len(labels))
+	for i, label := range labels {
+		ids[i] = label.ID
+	}
+	return ids
+}
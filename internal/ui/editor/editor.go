@@ -0,0 +1,70 @@
+// Package editor hands text to the user's editor, suspending the program
+// while it runs.
+package editor
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/linear-tui/linear-tui/internal/ui/messages"
+)
+
+// fallback is used when neither $VISUAL nor $EDITOR is set
+const fallback = "vi"
+
+// Open edits text in the user's editor as a temporary markdown file. Once
+// the editor exits, the saved text is sent back in an EditorFinishedMsg
+// carrying id, so the requester can recognise its result.
+func Open(id, text string) tea.Cmd {
+	file, err := os.CreateTemp("", "linear-tui-*.md")
+	if err != nil {
+		return failed(id, err)
+	}
+	path := file.Name()
+
+	_, err = file.WriteString(text)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(path)
+		return failed(id, err)
+	}
+
+	args := strings.Fields(command())
+	cmd := exec.Command(args[0], append(args[1:], path)...)
+
+	return tea.ExecProcess(cmd, func(err error) tea.Msg {
+		defer os.Remove(path)
+		if err != nil {
+			return messages.EditorFinishedMsg{ID: id, Err: err}
+		}
+
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return messages.EditorFinishedMsg{ID: id, Err: err}
+		}
+		return messages.EditorFinishedMsg{ID: id, Text: string(data)}
+	})
+}
+
+// command returns the editor to run, which may include arguments such as
+// `code --wait`
+func command() string {
+	for _, name := range []string{"VISUAL", "EDITOR"} {
+		if editor := strings.TrimSpace(os.Getenv(name)); editor != "" {
+			return editor
+		}
+	}
+	return fallback
+}
+
+// failed reports an error preparing the file before the editor could run
+func failed(id string, err error) tea.Cmd {
+	return func() tea.Msg {
+		return messages.EditorFinishedMsg{ID: id, Err: fmt.Errorf("failed to prepare file for editor: %w", err)}
+	}
+}
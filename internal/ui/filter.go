@@ -0,0 +1,110 @@
+package ui
+
+import (
+	"strings"
+
+	"github.com/linear-tui/linear-tui/internal/domain"
+	"github.com/linear-tui/linear-tui/internal/linear"
+	"github.com/linear-tui/linear-tui/internal/query"
+	"github.com/linear-tui/linear-tui/internal/ui/messages"
+)
+
+// workspaceResolver resolves names in filter queries against the teams,
+// users and projects the UI has loaded
+type workspaceResolver struct {
+	teams    []linear.Team
+	users    []linear.User
+	projects []domain.Project
+}
+
+// TeamID finds a team by key or by name. A unique name prefix also
+// matches.
+func (r workspaceResolver) TeamID(name string) (string, bool) {
+	var candidates []string
+	for _, team := range r.teams {
+		if strings.EqualFold(team.Key, name) || strings.EqualFold(team.Name, name) {
+			return team.ID, true
+		}
+		if hasPrefixFold(team.Name, name) {
+			candidates = append(candidates, team.ID)
+		}
+	}
+	if len(candidates) == 1 {
+		return candidates[0], true
+	}
+	return "", false
+}
+
+// UserID finds a user by name or by the part of their email before the @.
+// A unique name prefix also matches.
+func (r workspaceResolver) UserID(name string) (string, bool) {
+	var candidates []string
+	for _, user := range r.users {
+		handle, _, _ := strings.Cut(user.Email, "@")
+		if strings.EqualFold(user.Name, name) || strings.EqualFold(handle, name) {
+			return user.ID, true
+		}
+		if hasPrefixFold(user.Name, name) {
+			candidates = append(candidates, user.ID)
+		}
+	}
+	if len(candidates) == 1 {
+		return candidates[0], true
+	}
+	return "", false
+}
+
+// ProjectID finds a project by name. A unique name prefix also matches.
+func (r workspaceResolver) ProjectID(name string) (string, bool) {
+	var candidates []string
+	for _, project := range r.projects {
+		if strings.EqualFold(project.Name, name) {
+			return project.ID, true
+		}
+		if hasPrefixFold(project.Name, name) {
+			candidates = append(candidates, project.ID)
+		}
+	}
+	if len(candidates) == 1 {
+		return candidates[0], true
+	}
+	return "", false
+}
+
+func hasPrefixFold(s, prefix string) bool {
+	return len(s) >= len(prefix) && strings.EqualFold(s[:len(prefix)], prefix)
+}
+
+// textOnlyViews names the items of the views that can only be searched by
+// text, for errors about filters that cannot be applied to them
+var textOnlyViews = map[messages.ViewType]string{
+	messages.MyIssuesView:      "your issues",
+	messages.CreatedView:       "issues you created",
+	messages.ProjectView:       "projects",
+	messages.InboxView:         "notifications",
+	messages.CycleView:         "cycles",
+	messages.ProjectIssuesView: "issues of a project",
+}
+
+// checkTextQuery reports terms in a filter for a view that can only be
+// searched by text
+func checkTextQuery(q query.Query, view messages.ViewType) error {
+	if len(q.Terms) > 0 {
+		return &query.Error{Span: q.Terms[0].Span, Message: textOnlyViews[view] + " can only be searched by text"}
+	}
+	for _, text := range q.Text {
+		if text.Negated {
+			return &query.Error{Span: text.Span, Message: textOnlyViews[view] + " cannot be searched by negated text"}
+		}
+	}
+	return nil
+}
+
+// searchText returns the free text of a filter that has been applied
+func searchText(filter string) string {
+	q, err := query.Parse(filter)
+	if err != nil {
+		return ""
+	}
+	return q.SearchText()
+}
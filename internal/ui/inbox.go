@@ -0,0 +1,138 @@
+package ui
+
+import (
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/linear-tui/linear-tui/internal/domain"
+	"github.com/linear-tui/linear-tui/internal/ui/components/picker"
+	"github.com/linear-tui/linear-tui/internal/ui/messages"
+)
+
+// snoozePickerID identifies the snooze picker in PickerClosedMsg
+const snoozePickerID = "snooze"
+
+// snoozeOptions are the choices of how long to snooze a notification for
+func snoozeOptions() []picker.Option {
+	return []picker.Option{
+		{ID: "hour", Label: "1 hour"},
+		{ID: "evening", Label: "This evening", Detail: "6 pm"},
+		{ID: "tomorrow", Label: "Tomorrow", Detail: "9 am"},
+		{ID: "week", Label: "Next week", Detail: "Monday 9 am"},
+	}
+}
+
+// snoozeUntil returns the time a snooze option ends, counted from now
+func snoozeUntil(option string, now time.Time) time.Time {
+	at := func(days, hour int) time.Time {
+		return time.Date(now.Year(), now.Month(), now.Day()+days, hour, 0, 0, 0, now.Location())
+	}
+
+	switch option {
+	case "evening":
+		if evening := at(0, 18); evening.After(now) {
+			return evening
+		}
+		return at(1, 18)
+	case "tomorrow":
+		return at(1, 9)
+	case "week":
+		days := (8 - int(now.Weekday())) % 7
+		if days == 0 {
+			days = 7
+		}
+		return at(days, 9)
+	}
+	return now.Add(time.Hour)
+}
+
+// selectedNotification returns the notification under the cursor in the
+// inbox
+func (m Model) selectedNotification() (domain.Notification, bool) {
+	if m.focusArea != FocusMain || m.currentView != messages.InboxView {
+		return domain.Notification{}, false
+	}
+	notification, ok := m.listView.SelectedItem().(domain.Notification)
+	return notification, ok
+}
+
+// openNotification opens the issue a notification is about in the detail
+// pane, marking the notification read
+func (m *Model) openNotification(notification domain.Notification) tea.Cmd {
+	if notification.IssueID == "" {
+		m.footer.SetError("This notification is not about an issue")
+		return nil
+	}
+
+	var cmds []tea.Cmd
+	if !notification.Read {
+		cmds = append(cmds, m.markNotificationRead(notification, true))
+	}
+	return tea.Batch(append(cmds, loadIssueCmd(m.service, notification.IssueID))...)
+}
+
+// markNotificationRead marks a notification read or unread. The inbox
+// shows the change straight away; it is reloaded if saving it fails.
+func (m *Model) markNotificationRead(notification domain.Notification, read bool) tea.Cmd {
+	notification.Read = read
+	m.replaceNotification(notification)
+	return markNotificationReadCmd(m.service, notification.ID, read)
+}
+
+// openSnoozePicker opens the picker of how long to snooze the selected
+// notification for
+func (m *Model) openSnoozePicker(notification domain.Notification) tea.Cmd {
+	p := picker.New(snoozePickerID, "Snooze · "+notification.IssueIdentifier, snoozeOptions(), false)
+	p.SetSize(m.pickerSize())
+
+	m.snoozed = notification
+	m.actionPicker = &p
+	return p.Focus()
+}
+
+// snoozeNotification hides the notification picked to be snoozed from the
+// inbox until the time picked
+func (m *Model) snoozeNotification(msg messages.PickerClosedMsg) tea.Cmd {
+	if m.actionPicker == nil || msg.ID != snoozePickerID {
+		return nil
+	}
+	m.actionPicker = nil
+	if msg.Canceled || len(msg.Selected) == 0 {
+		return nil
+	}
+
+	notification := m.snoozed
+	var remaining []domain.Notification
+	for _, existing := range m.notifications {
+		if existing.ID != notification.ID {
+			remaining = append(remaining, existing)
+		}
+	}
+	m.notifications = remaining
+	if m.currentView == messages.InboxView {
+		m.listView.RemoveItem(notification)
+	}
+	return snoozeNotificationCmd(m.service, notification.ID, snoozeUntil(msg.Selected[0], time.Now()))
+}
+
+// replaceNotification shows a changed notification in place of the old one
+func (m *Model) replaceNotification(notification domain.Notification) {
+	for i := range m.notifications {
+		if m.notifications[i].ID == notification.ID {
+			m.notifications[i] = notification
+		}
+	}
+	if m.currentView == messages.InboxView {
+		m.listView.UpdateItem(notification)
+	}
+}
+
+// notificationUpdated reloads the inbox if a change to a notification could
+// not be saved, undoing the change shown
+func (m *Model) notificationUpdated(msg notificationUpdatedMsg) tea.Cmd {
+	if msg.err == nil {
+		return nil
+	}
+	m.footer.SetError("Could not update notification: " + msg.err.Error())
+	return loadNotificationsCmd(m.service, "")
+}
@@ -1,5 +1,10 @@
 package messages
 
+import (
+	"github.com/linear-tui/linear-tui/internal/domain"
+	"github.com/linear-tui/linear-tui/internal/query"
+)
+
 // TabSwitchedMsg is sent when the user switches tabs
 type TabSwitchedMsg struct{ Index int }
 
@@ -9,8 +14,131 @@ type ItemSelectedMsg struct{ Item interface{} }
 // CloseDetailPaneMsg is sent when the detail pane should be closed
 type CloseDetailPaneMsg struct{}
 
-// DataLoadedMsg is sent when data is loaded from the API
-type DataLoadedMsg struct{ Items []interface{} }
+// DataLoadedMsg is sent when data for a view is loaded from the API. When
+// Append is set the items are a further page of the view's existing items.
+// Err is set if loading failed, which is shown in the view rather than
+// replacing the whole screen. Generation is the generation of the query
+// or teams the view was loaded for, so that pages loaded for earlier ones
+// are dropped.
+type DataLoadedMsg struct {
+	View       ViewType
+	Items      []interface{}
+	NextCursor string
+	Append     bool
+	Err        error
+	Generation int
+}
+
+// LoadMoreMsg is sent when a view needs the page of items after Cursor
+type LoadMoreMsg struct {
+	View   ViewType
+	Cursor string
+}
+
+// FilterSubmittedMsg is sent when a filter query is entered in the filter bar
+type FilterSubmittedMsg struct {
+	Text  string
+	Query query.Query
+}
+
+// SearchChangedMsg is sent as the free text of the filter being edited
+// changes, so the list can be searched incrementally
+type SearchChangedMsg struct{ Text string }
+
+// FilterClosedMsg is sent when the filter bar is closed without submitting
+type FilterClosedMsg struct{}
+
+// CommentsLoadedMsg is sent when the comments on an issue have been
+// fetched, or fetching them failed
+type CommentsLoadedMsg struct {
+	IssueID  string
+	Comments []domain.Comment
+	Err      error
+}
+
+// HistoryLoadedMsg is sent when the history of an issue has been fetched,
+// or fetching it failed
+type HistoryLoadedMsg struct {
+	IssueID string
+	Events  []domain.HistoryEvent
+	Err     error
+}
+
+// RelationsLoadedMsg is sent when the issues linked to an issue have been
+// fetched, or fetching them failed
+type RelationsLoadedMsg struct {
+	IssueID   string
+	Relations *domain.IssueRelations
+	Err       error
+}
+
+// OpenIssueMsg asks for the issue with a Linear ID to be fetched and shown
+// in the detail pane, such as when following a link to it
+type OpenIssueMsg struct{ IssueID string }
+
+// CommentSubmittedMsg is sent when a comment has been written and should
+// be posted
+type CommentSubmittedMsg struct {
+	IssueID string
+	Body    string
+}
+
+// CommentCreatedMsg is sent when posting a comment has finished
+type CommentCreatedMsg struct {
+	IssueID string
+	Comment *domain.Comment
+	Err     error
+}
+
+// EditorFinishedMsg is sent when the external editor exits. ID identifies
+// what was being edited.
+type EditorFinishedMsg struct {
+	ID   string
+	Text string
+	Err  error
+}
+
+// IssueSubmittedMsg is sent when the issue form has been filled in and the
+// issue should be created
+type IssueSubmittedMsg struct{ Issue domain.NewIssue }
+
+// IssueCreatedMsg is sent when creating an issue has finished
+type IssueCreatedMsg struct {
+	Issue *domain.Issue
+	Err   error
+}
+
+// CardMovedMsg is sent when an issue is moved to another column of the
+// board, which should change its state to StateID
+type CardMovedMsg struct {
+	Issue   domain.Issue
+	StateID string
+}
+
+// IssueUpdatedMsg is sent when updating an issue has finished. IssueID is
+// the Linear ID of the issue, and Seq the number the service gave the
+// update when it was shown.
+type IssueUpdatedMsg struct {
+	IssueID string
+	Seq     int
+	Issue   *domain.Issue
+	Err     error
+}
+
+// IssueFormTeamChangedMsg is sent when a team is chosen in the issue form,
+// whose states and labels are then needed for its pickers
+type IssueFormTeamChangedMsg struct{ TeamID string }
+
+// PickerClosedMsg is sent when a picker closes. ID identifies the picker;
+// Selected holds the IDs of the chosen options unless it was canceled.
+type PickerClosedMsg struct {
+	ID       string
+	Selected []string
+	Canceled bool
+}
+
+// ErrorMsg is sent when a background operation fails
+type ErrorMsg struct{ Err error }
 
 // ViewType represents the type of view being displayed
 type ViewType int
@@ -18,4 +146,25 @@ type ViewType int
 const (
 	IssueView ViewType = iota
 	ProjectView
-)
\ No newline at end of file
+	BoardView
+
+	// MyIssuesView and CreatedView list the open issues assigned to and
+	// created by the authenticated user
+	MyIssuesView
+	CreatedView
+
+	// InboxView lists the authenticated user's notifications
+	InboxView
+
+	// CycleView lists the cycles of the teams shown
+	CycleView
+
+	// ProjectIssuesView lists the issues of the project opened from the
+	// Projects view
+	ProjectIssuesView
+)
+
+// ListsIssues reports whether the view is a list of issues
+func (v ViewType) ListsIssues() bool {
+	return v == IssueView || v == MyIssuesView || v == CreatedView || v == ProjectIssuesView
+}
@@ -0,0 +1,97 @@
+package ui
+
+import (
+	"github.com/linear-tui/linear-tui/internal/domain"
+	"github.com/linear-tui/linear-tui/internal/linear"
+	"github.com/linear-tui/linear-tui/internal/ui/components/picker"
+)
+
+// priorityOptions returns the picker options for priorities, whose IDs are
+// Linear priority numbers
+func priorityOptions() []picker.Option {
+	return []picker.Option{
+		{ID: "0", Label: "No priority"},
+		{ID: "1", Label: "Urgent"},
+		{ID: "2", Label: "High"},
+		{ID: "3", Label: "Normal"},
+		{ID: "4", Label: "Low"},
+	}
+}
+
+// teamOptions returns the picker options for teams
+func teamOptions(teams []linear.Team) []picker.Option {
+	options := make([]picker.Option, len(teams))
+	for i, team := range teams {
+		options[i] = picker.Option{ID: team.ID, Label: team.Name, Detail: team.Key}
+	}
+	return options
+}
+
+// userOptions returns the picker options for users, showing their email so
+// that users with the same name can be told apart
+func userOptions(users []linear.User) []picker.Option {
+	options := make([]picker.Option, len(users))
+	for i, user := range users {
+		options[i] = picker.Option{ID: user.ID, Label: user.Name, Detail: user.Email}
+	}
+	return options
+}
+
+// projectOptions returns the picker options for projects
+func projectOptions(projects []domain.Project) []picker.Option {
+	options := make([]picker.Option, len(projects))
+	for i, project := range projects {
+		options[i] = picker.Option{ID: project.ID, Label: project.Name, Detail: project.Status}
+	}
+	return options
+}
+
+// stateOptions returns the picker options for workflow states
+func stateOptions(states []linear.IssueState) []picker.Option {
+	options := make([]picker.Option, len(states))
+	for i, state := range states {
+		options[i] = picker.Option{ID: state.ID, Label: state.Name, Color: state.Color}
+	}
+	return options
+}
+
+// labelOptions returns the picker options for labels, noting which belong
+// to the whole workspace rather than a team
+func labelOptions(labels []linear.IssueLabel) []picker.Option {
+	options := make([]picker.Option, len(labels))
+	for i, label := range labels {
+		detail := "Workspace"
+		if label.Team != nil {
+			detail = label.Team.Name
+		}
+		options[i] = picker.Option{ID: label.ID, Label: label.Name, Detail: detail, Color: label.Color}
+	}
+	return options
+}
+
+// cycleOptions returns the picker options for the cycles that have not
+// ended, with their dates
+func cycleOptions(cycles []domain.Cycle) []picker.Option {
+	var options []picker.Option
+	for _, cycle := range cycles {
+		if cycle.Status == "Past" {
+			continue
+		}
+		detail := cycle.StartsAt.Format("Jan 02") + " – " + cycle.EndsAt.Format("Jan 02")
+		if cycle.Status == "Current" {
+			detail = "Current · " + detail
+		}
+		options = append(options, picker.Option{ID: cycle.ID, Label: cycle.Name, Detail: detail})
+	}
+	return options
+}
+
+// issueOptions returns the picker options for issues, labelled with their
+// identifier so that they can be searched by it
+func issueOptions(issues []domain.Issue) []picker.Option {
+	options := make([]picker.Option, len(issues))
+	for i, issue := range issues {
+		options[i] = picker.Option{ID: issue.LinearID, Label: issue.ID + " " + issue.Title, Detail: issue.Status}
+	}
+	return options
+}
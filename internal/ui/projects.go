@@ -0,0 +1,58 @@
+package ui
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/linear-tui/linear-tui/internal/domain"
+	"github.com/linear-tui/linear-tui/internal/linear"
+	"github.com/linear-tui/linear-tui/internal/query"
+	"github.com/linear-tui/linear-tui/internal/ui/messages"
+)
+
+// projectQuery returns the query of the issues of a project
+func projectQuery(projectID string) query.Compiled {
+	return query.Compiled{Filter: linear.IssueFilter{ProjectID: projectID}}
+}
+
+// openProject shows a project's details above the list of its issues, in
+// place of the list of projects
+func (m *Model) openProject(project domain.Project) tea.Cmd {
+	m.openedProject = &project
+	m.projectIssues = nil
+	m.projectView.SetProject(project)
+
+	m.currentView = messages.ProjectIssuesView
+	m.filterBar.SetApplied("")
+	delete(m.filters, messages.ProjectIssuesView)
+	m.listView.SetView(messages.ProjectIssuesView, nil, "")
+	m.listView.SetSearch("")
+	m.updateComponentSizes()
+
+	if m.service == nil {
+		return nil
+	}
+	return tea.Batch(
+		loadProjectDetailsCmd(m.service, project.ID),
+		loadProjectIssuesCmd(m.service, project.ID, ""),
+	)
+}
+
+// closeProject goes back from an opened project to the list of projects,
+// with the project selected
+func (m *Model) closeProject() {
+	project := *m.openedProject
+	m.openedProject = nil
+	m.projectIssues = nil
+
+	m.currentView = messages.ProjectView
+	filter := m.filters[messages.ProjectView]
+	m.filterBar.SetApplied(filter)
+	m.listView.SetView(messages.ProjectView, m.itemsFor(messages.ProjectView), m.nextCursors[messages.ProjectView])
+	m.listView.SetSearch(searchText(filter))
+	m.listView.Select(project)
+	m.updateComponentSizes()
+}
+
+// projectShown reports whether the project with an ID is open
+func (m Model) projectShown(projectID string) bool {
+	return m.openedProject != nil && m.openedProject.ID == projectID
+}
@@ -0,0 +1,110 @@
+package ui
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/linear-tui/linear-tui/internal/domain"
+	"github.com/linear-tui/linear-tui/internal/ui/components/picker"
+	"github.com/linear-tui/linear-tui/internal/ui/messages"
+)
+
+// relationPickerID and linkPickerID identify the pickers of how to link an
+// issue and what to link it to in PickerClosedMsg
+const (
+	relationPickerID = "relation"
+	linkPickerID     = "link"
+)
+
+// issueLink is a link from an issue being made. The relation is picked
+// first, then the issue to link to.
+type issueLink struct {
+	issue    domain.Issue
+	relation string
+}
+
+// relationOptions are the ways an issue can be linked to another one
+func relationOptions() []picker.Option {
+	relations := []string{
+		domain.RelationBlocks,
+		domain.RelationBlockedBy,
+		domain.RelationRelated,
+		domain.RelationDuplicateOf,
+		domain.RelationDuplicateBy,
+	}
+	options := make([]picker.Option, len(relations))
+	for i, relation := range relations {
+		options[i] = picker.Option{ID: relation, Label: relation}
+	}
+	return options
+}
+
+// openRelationPicker starts linking an issue to another one by picking how
+func (m *Model) openRelationPicker(issue domain.Issue) tea.Cmd {
+	p := picker.New(relationPickerID, "Link · "+issue.ID, relationOptions(), false)
+	p.SetSize(m.pickerSize())
+
+	m.linking = &issueLink{issue: issue}
+	m.actionPicker = &p
+	return p.Focus()
+}
+
+// pickRelation opens the picker of the issue to link to once the relation
+// has been picked. Any loaded issue can be picked.
+func (m *Model) pickRelation(msg messages.PickerClosedMsg) tea.Cmd {
+	if m.actionPicker == nil || m.linking == nil || msg.ID != relationPickerID {
+		return nil
+	}
+	m.actionPicker = nil
+	if msg.Canceled || len(msg.Selected) == 0 {
+		m.linking = nil
+		return nil
+	}
+	m.linking.relation = msg.Selected[0]
+
+	var issues []domain.Issue
+	seen := map[string]bool{m.linking.issue.LinearID: true}
+	for _, loaded := range [][]domain.Issue{m.issues, m.myIssues, m.createdIssues, m.projectIssues} {
+		for _, issue := range loaded {
+			if !seen[issue.LinearID] {
+				seen[issue.LinearID] = true
+				issues = append(issues, issue)
+			}
+		}
+	}
+
+	p := picker.New(linkPickerID, m.linking.relation+" · "+m.linking.issue.ID, issueOptions(issues), false)
+	p.SetSize(m.pickerSize())
+	m.actionPicker = &p
+	return p.Focus()
+}
+
+// linkIssue links the issue being linked to the one picked
+func (m *Model) linkIssue(msg messages.PickerClosedMsg) tea.Cmd {
+	if m.actionPicker == nil || m.linking == nil || msg.ID != linkPickerID {
+		return nil
+	}
+	link := *m.linking
+	m.actionPicker, m.linking = nil, nil
+	if msg.Canceled || len(msg.Selected) == 0 {
+		return nil
+	}
+	return linkIssuesCmd(m.service, link.issue.LinearID, msg.Selected[0], link.relation)
+}
+
+// issuesLinked shows the new link if the linked issue is open in the
+// detail pane
+func (m *Model) issuesLinked(msg issuesLinkedMsg) tea.Cmd {
+	if msg.err != nil {
+		m.footer.SetError("Could not link issues: " + msg.err.Error())
+		return nil
+	}
+	return m.reloadRelations(msg.issueID)
+}
+
+// reloadRelations reloads the issues linked to an issue if it is open in
+// the detail pane
+func (m *Model) reloadRelations(issueID string) tea.Cmd {
+	if !m.showsIssue(issueID) {
+		return nil
+	}
+	return loadRelationsCmd(m.service, issueID)
+}
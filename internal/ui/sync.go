@@ -0,0 +1,89 @@
+package ui
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/linear-tui/linear-tui/internal/domain"
+	"github.com/linear-tui/linear-tui/internal/linear"
+	"github.com/linear-tui/linear-tui/internal/ui/messages"
+)
+
+// loadViews loads the issue lists and projects: from the cache where it
+// can show them, and otherwise from Linear, in which case refresh leaves
+// the view as it is
+func (m Model) loadViews(refresh bool) []tea.Cmd {
+	fallback := func(cmd tea.Cmd) tea.Cmd {
+		if refresh {
+			return nil
+		}
+		return cmd
+	}
+
+	var cmds []tea.Cmd
+	for _, view := range []messages.ViewType{messages.IssueView, messages.MyIssuesView, messages.CreatedView} {
+		q := m.queryFor(view)
+		cmd := loadCachedIssuesCmd(m.service, view, q, fallback(loadIssuesCmd(m.service, view, q, "")))
+		cmds = append(cmds, tagGeneration(cmd, m.generations[view]))
+	}
+	return append(cmds, loadCachedProjectsCmd(m.service, fallback(loadProjectsCmd(m.service, ""))))
+}
+
+// synced shows the lists from the cache once a sync has brought it up to
+// date, and reports the queued edits that could not be sent. A failed sync
+// leaves the lists as they are, unless it found the API key no longer
+// valid or queued edits were sent; failing to reach Linear is shown by the
+// footer alone.
+func (m *Model) synced(msg syncedMsg) tea.Cmd {
+	m.syncing = false
+	var linearErr *linear.LinearError
+	if errors.As(msg.syncErr, &linearErr) && linearErr.Type == linear.ErrorTypeAuth {
+		m.err = msg.syncErr
+		return nil
+	}
+
+	var problems []string
+	if msg.syncErr != nil && !isNetworkError(msg.syncErr) {
+		problems = append(problems, "Could not sync with Linear: "+msg.syncErr.Error())
+	}
+	if msg.replayErr != nil && !isNetworkError(msg.replayErr) {
+		problems = append(problems, "Could not send queued edits: "+msg.replayErr.Error())
+	}
+	if dropped := msg.report.Dropped; len(dropped) > 0 {
+		problems = append(problems, fmt.Sprintf("%d queued edit(s) not sent: %s", len(dropped), strings.Join(dropped, "; ")))
+	}
+	if len(problems) > 0 {
+		m.footer.SetError(strings.Join(problems, " · "))
+	}
+	if msg.syncErr != nil && msg.report.Sent == 0 && len(msg.report.Dropped) == 0 {
+		return nil
+	}
+
+	m.tabs.SetLabel(m.teamLabel())
+	m.detailPane.SetUserNames(mentionNames(m.service.GetUsers()))
+	if item, ok := m.detailPane.Item().(domain.Issue); ok {
+		if issue, ok := m.service.CachedTicket(item.LinearID); ok {
+			m.detailPane.UpdateIssue(*issue)
+		}
+	}
+	return tea.Batch(m.loadViews(true)...)
+}
+
+// syncIfStale syncs the cache when it has not been synced for a while,
+// Linear could not be reached or edits are waiting to be sent, and checks
+// again later
+func (m *Model) syncIfStale() tea.Cmd {
+	if m.service == nil || m.syncing || !(m.service.IsDataStale() || m.service.Offline() || m.service.QueuedEdits() > 0) {
+		return syncTickCmd()
+	}
+	m.syncing = true
+	return tea.Batch(syncCmd(m.service), syncTickCmd())
+}
+
+// isNetworkError reports whether err is a failure to reach Linear
+func isNetworkError(err error) bool {
+	var linearErr *linear.LinearError
+	return errors.As(err, &linearErr) && linearErr.Type == linear.ErrorTypeNetwork
+}
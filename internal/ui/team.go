@@ -0,0 +1,115 @@
+package ui
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/linear-tui/linear-tui/internal/query"
+	"github.com/linear-tui/linear-tui/internal/ui/components/picker"
+	"github.com/linear-tui/linear-tui/internal/ui/messages"
+)
+
+// teamPickerID identifies the team picker in PickerClosedMsg
+const teamPickerID = "team"
+
+// allTeamsOptionID is the ID of the team picker option for every team.
+// Linear IDs are UUIDs, so it cannot clash with a team.
+const allTeamsOptionID = "*"
+
+// openTeamPicker opens the picker that switches the team whose issues are
+// shown, with the current choice selected
+func (m *Model) openTeamPicker() tea.Cmd {
+	options := append([]picker.Option{{ID: allTeamsOptionID, Label: "All teams"}}, teamOptions(m.service.GetTeams())...)
+
+	p := picker.New(teamPickerID, "Team", options, false)
+	if m.service.AllTeams() {
+		p.Select(allTeamsOptionID)
+	} else if team := m.service.GetDefaultTeam(); team != nil {
+		p.Select(team.ID)
+	}
+	p.SetSize(m.pickerSize())
+
+	m.actionPicker = &p
+	return p.Focus()
+}
+
+// switchTeam shows the issues of the team picked in the team picker, and
+// saves the choice so that it is kept the next time the app starts
+func (m *Model) switchTeam(msg messages.PickerClosedMsg) tea.Cmd {
+	if m.actionPicker == nil || msg.ID != teamPickerID {
+		return nil
+	}
+	m.actionPicker = nil
+	if msg.Canceled || len(msg.Selected) == 0 {
+		return nil
+	}
+
+	selected := msg.Selected[0]
+	allTeams := selected == allTeamsOptionID
+	if !allTeams {
+		if err := m.service.SetDefaultTeam(selected); err != nil {
+			m.footer.SetError(err.Error())
+			return nil
+		}
+	}
+	m.service.SetAllTeams(allTeams)
+	m.tabs.SetLabel(m.teamLabel())
+
+	m.cfg.AllTeams = allTeams
+	if team := m.service.GetDefaultTeam(); team != nil {
+		m.cfg.DefaultTeamID = team.ID
+	}
+	if err := m.cfg.Save(); err != nil {
+		m.footer.SetError("Could not save the team: " + err.Error())
+	}
+
+	// The issue filter is compiled on top of the team filter, so it is
+	// compiled again for the new team
+	q, err := query.Parse(m.filters[messages.IssueView])
+	if err != nil {
+		q = query.Query{}
+	}
+	load := m.applyQuery(q)
+	if load == nil {
+		// A filter that does not compile for the new team is dropped, so
+		// that the issues of the old team are never left showing
+		if m.filterView() == messages.IssueView {
+			m.filterBar.Clear()
+			m.listView.SetSearch("")
+		}
+		delete(m.filters, messages.IssueView)
+		m.updateComponentSizes()
+		m.footer.SetError("The filter does not apply to the new team, so it was cleared")
+		load = m.applyQuery(query.Query{})
+	}
+
+	// Cycles still loading for the old team are dropped too
+	m.generations[messages.CycleView]++
+	m.board.SetColumns(nil)
+	return tea.Batch(load, loadBoardCmd(m.service, m.boardTeamIDs()), tagGeneration(loadCyclesCmd(m.service, m.boardTeamIDs()), m.generations[messages.CycleView]))
+}
+
+// boardTeamIDs returns the IDs of the teams whose issues are on the board
+func (m Model) boardTeamIDs() []string {
+	if m.service.AllTeams() {
+		teams := m.service.GetTeams()
+		ids := make([]string, len(teams))
+		for i, team := range teams {
+			ids[i] = team.ID
+		}
+		return ids
+	}
+	if team := m.service.GetDefaultTeam(); team != nil {
+		return []string{team.ID}
+	}
+	return nil
+}
+
+// teamLabel names the team whose issues are shown
+func (m Model) teamLabel() string {
+	if m.service.AllTeams() {
+		return "All teams"
+	}
+	if team := m.service.GetDefaultTeam(); team != nil {
+		return team.Name
+	}
+	return ""
+}
@@ -1,12 +1,28 @@
 package ui
 
 import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/spinner"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/linear-tui/linear-tui/internal/config"
 	"github.com/linear-tui/linear-tui/internal/domain"
+	"github.com/linear-tui/linear-tui/internal/linear"
+	"github.com/linear-tui/linear-tui/internal/query"
+	"github.com/linear-tui/linear-tui/internal/services"
+	"github.com/linear-tui/linear-tui/internal/ui/components/board"
 	"github.com/linear-tui/linear-tui/internal/ui/components/detailpane"
+	"github.com/linear-tui/linear-tui/internal/ui/components/filterbar"
 	"github.com/linear-tui/linear-tui/internal/ui/components/footer"
+	"github.com/linear-tui/linear-tui/internal/ui/components/issueform"
 	"github.com/linear-tui/linear-tui/internal/ui/components/listview"
+	"github.com/linear-tui/linear-tui/internal/ui/components/picker"
+	"github.com/linear-tui/linear-tui/internal/ui/components/projectview"
 	"github.com/linear-tui/linear-tui/internal/ui/components/tabs"
 	"github.com/linear-tui/linear-tui/internal/ui/messages"
 )
@@ -17,59 +33,331 @@ const (
 	FocusMain FocusArea = iota
 	FocusDetailPane
 	FocusTabs
+	FocusFilterBar
+	FocusIssueForm
 )
 
+// tabViews are the views of the tabs, in order
+var tabViews = []messages.ViewType{
+	messages.IssueView, messages.MyIssuesView, messages.CreatedView,
+	messages.ProjectView, messages.CycleView, messages.BoardView, messages.InboxView,
+}
+
 type Model struct {
 	width  int
 	height int
 
 	// Child Componennts
-	tabs       tabs.Model
-	listView   listview.Model
-	detailPane detailpane.Model
-	footer     footer.Model
+	tabs        tabs.Model
+	listView    listview.Model
+	board       board.Model
+	detailPane  detailpane.Model
+	projectView projectview.Model
+	filterBar   filterbar.Model
+	issueForm   issueform.Model
+	footer      footer.Model
+	spinner     spinner.Model
 
 	// State
 	currentView    messages.ViewType
 	focusArea      FocusArea
 	detailPaneOpen bool
+	loading        bool
+	err            error
+
+	// Services
+	cfg     *config.Config
+	service *services.LinearService
 
 	// Data
-	issues   []domain.Issue
-	projects []domain.Project
+	issues      []domain.Issue
+	projects    []domain.Project
+	nextCursors map[messages.ViewType]string
+	issueQuery  query.Compiled
+
+	// generations counts the queries applied to each view, or the team
+	// switches for the cycles, so that pages loaded for earlier ones are
+	// told apart
+	generations map[messages.ViewType]int
+
+	// loadErrors holds why the first page of a view could not be loaded
+	loadErrors map[messages.ViewType]error
+
+	// myIssues and createdIssues are the issues of the My Issues and
+	// Created by me views, and notifications those of the inbox
+	myIssues      []domain.Issue
+	createdIssues []domain.Issue
+	notifications []domain.Notification
+
+	// cycles are the cycles of the teams shown
+	cycles []domain.Cycle
+
+	// openedProject is the project opened from the Projects view, if any,
+	// and projectIssues its issues
+	openedProject *domain.Project
+	projectIssues []domain.Issue
+
+	// filters holds the filter text applied to each view
+	filters map[messages.ViewType]string
+
+	// edit is the in-place edit of an issue in progress, if any
+	edit *issueEdit
+
+	// actionPicker is the open picker of an action other than an edit, such
+	// as switching teams, if any
+	actionPicker *picker.Model
+
+	// snoozed is the notification the snooze picker was opened for
+	snoozed domain.Notification
+
+	// linking is the link between issues being made, if any
+	linking *issueLink
+
+	// syncing is set while the cache is being synced with Linear
+	syncing bool
+
+	// changes are the changes made that can be undone and redone
+	changes undoHistory
+
+	// bulk is the bulk action in progress on the issues marked in a list,
+	// if any, and bulkReport lists the issues the last one failed on until
+	// it is closed
+	bulk       *bulkJob
+	bulkReport *bulkReport
 
 	styles Styles
 }
 
-func NewModel() Model {
+func NewModel(cfg *config.Config) Model {
+	sp := spinner.New()
+	sp.Spinner = spinner.Dot
+
+	detailPane := detailpane.New()
+	detailPane.SetExternalEditor(cfg.ExternalEditor)
+
 	return Model{
-		tabs:       tabs.New([]string{"Issues", "Projects"}),
-		listView:   listview.New(),
-		detailPane: detailpane.New(),
-		footer:     footer.New(),
+		tabs:        tabs.New([]string{"Issues", "My Issues", "Created by me", "Projects", "Cycles", "Board", "Inbox"}),
+		listView:    listview.New(),
+		board:       board.New(),
+		detailPane:  detailPane,
+		projectView: projectview.New(),
+		filterBar:   filterbar.New(),
+		issueForm:   issueform.New(),
+		footer:      footer.New(),
+		spinner:     sp,
 
 		currentView:    messages.IssueView,
 		focusArea:      FocusMain,
 		detailPaneOpen: false,
+		loading:        true,
+
+		cfg:         cfg,
+		nextCursors: make(map[messages.ViewType]string),
+		loadErrors:  make(map[messages.ViewType]error),
+		generations: make(map[messages.ViewType]int),
+		filters:     make(map[messages.ViewType]string),
+		changes:     newUndoHistory(),
+		styles:      *NewStyles(),
 	}
 }
 
 func (m Model) Init() tea.Cmd {
-	return nil
+	return tea.Batch(m.spinner.Tick, initServiceCmd(m.cfg))
 }
 
 func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	var cmds []tea.Cmd
 
+	if m.service != nil {
+		m.footer.SetConnection(!m.service.Offline(), m.service.QueuedEdits())
+	}
+
 	switch msg := msg.(type) {
 	case tea.WindowSizeMsg:
 		m.width, m.height = msg.Width, msg.Height
 		m.updateComponentSizes()
 
+	case spinner.TickMsg:
+		if !m.loading {
+			return m, nil
+		}
+		var cmd tea.Cmd
+		m.spinner, cmd = m.spinner.Update(msg)
+		return m, cmd
+
+	case serviceReadyMsg:
+		m.service = msg.service
+		m.issueQuery = query.Compiled{Filter: m.service.DefaultIssueFilter()}
+		m.detailPane.SetUserNames(mentionNames(m.service.GetUsers()))
+		m.tabs.SetLabel(m.teamLabel())
+
+		// Lists are shown from the cache where it can, and the sync that
+		// follows brings them up to date
+		m.syncing = true
+		return m, tea.Batch(append(m.loadViews(false),
+			loadNotificationsCmd(m.service, ""),
+			loadCyclesCmd(m.service, m.boardTeamIDs()),
+			loadBoardCmd(m.service, m.boardTeamIDs()),
+			syncCmd(m.service),
+			syncTickCmd(),
+		)...)
+
+	case syncedMsg:
+		return m, m.synced(msg)
+
+	case syncTickMsg:
+		return m, m.syncIfStale()
+
+	case boardStatesLoadedMsg:
+		// States loaded for the teams shown before a team switch are stale
+		if !slices.Equal(msg.teamIDs, m.boardTeamIDs()) {
+			return m, nil
+		}
+		if msg.err != nil {
+			m.board.SetError(msg.err)
+		} else {
+			m.board.SetColumns(boardColumns(msg.teamIDs, msg.states))
+		}
+		return m, nil
+
+	case messages.ErrorMsg:
+		m.loading = false
+		// Offline, what cannot be shown from the cache is left out rather
+		// than keeping the cached workspace from being browsed
+		if m.service != nil && m.service.Synced() && isNetworkError(msg.Err) {
+			m.footer.SetError("Offline, could not load: " + msg.Err.Error())
+			return m, nil
+		}
+		m.err = msg.Err
+		return m, nil
+
 	case tea.KeyMsg:
+		if m.loading || m.err != nil {
+			return m.handleBlockingKey(msg)
+		}
+
+		// Any key closes the report of a bulk action
+		if m.bulkReport != nil && msg.String() != "ctrl+c" {
+			m.bulkReport = nil
+			return m, nil
+		}
+
+		// An edit takes all keys while its picker is open
+		if m.edit != nil && msg.String() != "ctrl+c" {
+			return m.updateEdit(msg)
+		}
+
+		// So does the picker of any other action
+		if m.actionPicker != nil && msg.String() != "ctrl+c" {
+			var cmd tea.Cmd
+			*m.actionPicker, cmd = m.actionPicker.Update(msg)
+			return m, cmd
+		}
+
+		// The filter bar takes all keys while it is being edited
+		if m.focusArea == FocusFilterBar && msg.String() != "ctrl+c" {
+			var cmd tea.Cmd
+			m.filterBar, cmd = m.filterBar.Update(msg)
+			return m, cmd
+		}
+
+		// So does the issue form while it is open
+		if m.focusArea == FocusIssueForm && msg.String() != "ctrl+c" {
+			var cmd tea.Cmd
+			m.issueForm, cmd = m.issueForm.Update(msg)
+			if !m.issueForm.Active() {
+				m.focusArea = FocusMain
+			}
+			return m, cmd
+		}
+
+		// So does the detail pane while a comment is being written
+		if m.focusArea == FocusDetailPane && m.detailPane.InputActive() && msg.String() != "ctrl+c" {
+			var cmd tea.Cmd
+			m.detailPane, cmd = m.detailPane.Update(msg)
+			return m, cmd
+		}
+
 		switch msg.String() {
 		case "q", "ctrl+c":
 			return m, tea.Quit
+		case "/":
+			if m.focusArea == FocusMain {
+				m.focusArea = FocusFilterBar
+				m.listView.Blur()
+				cmd := m.filterBar.Focus()
+				m.updateComponentSizes()
+				return m, cmd
+			}
+		case "s", "p", "a", "P", "C", "l", "e":
+			// On the board l moves between columns
+			if msg.String() == "l" && m.focusArea == FocusMain && m.currentView == messages.BoardView {
+				break
+			}
+			if bulkKeys[msg.String()] && m.bulkActive() && m.service != nil {
+				return m, m.startBulk(msg.String())
+			}
+			if issue, ok := m.selectedIssue(); ok && m.service != nil {
+				return m, m.startEdit(issue, editKeys[msg.String()])
+			}
+		case "T":
+			if m.service != nil {
+				return m, m.openTeamPicker()
+			}
+		case "n":
+			if m.focusArea == FocusMain && (m.currentView.ListsIssues() || m.currentView == messages.BoardView) && m.service != nil {
+				return m, m.openIssueForm(nil)
+			}
+		case "N":
+			if issue, ok := m.selectedIssue(); ok && m.service != nil {
+				return m, m.openIssueForm(&issue)
+			}
+		case "R":
+			if issue, ok := m.selectedIssue(); ok && m.service != nil {
+				return m, m.openRelationPicker(issue)
+			}
+		case "r":
+			if notification, ok := m.selectedNotification(); ok && m.service != nil {
+				return m, m.markNotificationRead(notification, !notification.Read)
+			}
+		case "z":
+			if notification, ok := m.selectedNotification(); ok && m.service != nil {
+				return m, m.openSnoozePicker(notification)
+			}
+		case "X":
+			if m.bulkActive() && m.service != nil {
+				return m, m.startBulk(msg.String())
+			}
+			if issue, ok := m.selectedIssue(); ok && m.service != nil {
+				return m, archiveIssueCmd(m.service, issue)
+			}
+		case "u":
+			if m.service != nil {
+				return m, m.undo(opUndo)
+			}
+		case "ctrl+r":
+			if m.service != nil {
+				return m, m.undo(opRedo)
+			}
+		case "esc":
+			if m.bulkActive() {
+				m.listView.ClearMarks()
+				return m, nil
+			}
+			if m.focusArea == FocusMain && m.filterBar.Applied() != "" {
+				m.filterBar.Clear()
+				m.listView.SetSearch("")
+				delete(m.filters, m.filterView())
+				m.updateComponentSizes()
+				if m.filterView() == messages.IssueView {
+					return m, m.applyQuery(query.Query{})
+				}
+				return m, nil
+			}
+			if m.focusArea == FocusMain && m.openedProject != nil {
+				m.closeProject()
+				return m, nil
+			}
 		case "tab":
 			// Cycle through focus areas
 			switch m.focusArea {
@@ -86,21 +374,260 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 		}
 
+	case messages.DataLoadedMsg:
+		// Pages still in flight when the filter or team changed belong to
+		// the old query
+		if msg.Generation != m.generations[msg.View] {
+			return m, nil
+		}
+		if msg.Err != nil {
+			m.loadFailed(msg)
+			break
+		}
+		delete(m.loadErrors, msg.View)
+		m.nextCursors[msg.View] = msg.NextCursor
+		switch msg.View {
+		case messages.IssueView:
+			issues := issuesFromItems(msg.Items)
+			if msg.Append {
+				issues = appendIssues(m.issues, issues)
+			}
+			m.issues = issues
+			m.board.SetIssues(m.issues)
+			// Issues are the initial view, so they end the loading screen
+			m.loading = false
+
+			// The board shows every issue matching the filter, so it keeps
+			// loading pages while it is shown
+			if m.currentView == messages.BoardView && msg.NextCursor != "" && m.service != nil {
+				cmds = append(cmds, m.loadIssueListCmd(msg.NextCursor))
+			}
+		case messages.MyIssuesView:
+			issues := issuesFromItems(msg.Items)
+			if msg.Append {
+				issues = appendIssues(m.myIssues, issues)
+			}
+			m.myIssues = issues
+		case messages.CreatedView:
+			issues := issuesFromItems(msg.Items)
+			if msg.Append {
+				issues = appendIssues(m.createdIssues, issues)
+			}
+			m.createdIssues = issues
+		case messages.ProjectIssuesView:
+			issues := issuesFromItems(msg.Items)
+			if msg.Append {
+				issues = appendIssues(m.projectIssues, issues)
+			}
+			m.projectIssues = issues
+		case messages.ProjectView:
+			projects := projectsFromItems(msg.Items)
+			if msg.Append {
+				projects = appendProjects(m.projects, projects)
+			}
+			m.projects = projects
+		case messages.InboxView:
+			notifications := notificationsFromItems(msg.Items)
+			if msg.Append {
+				notifications = append(m.notifications, notifications...)
+			}
+			m.notifications = notifications
+		case messages.CycleView:
+			m.cycles = cyclesFromItems(msg.Items)
+		}
+
+	case messages.LoadMoreMsg:
+		if m.service != nil {
+			cmd := loadMoreCmd(m.service, msg.View, m.queryFor(msg.View), msg.Cursor)
+			cmds = append(cmds, tagGeneration(cmd, m.generations[msg.View]))
+		}
+
+	case messages.FilterSubmittedMsg:
+		var cmd tea.Cmd
+		if m.filterView() == messages.IssueView {
+			if cmd = m.applyQuery(msg.Query); cmd == nil {
+				return m, nil
+			}
+		} else if err := checkTextQuery(msg.Query, m.filterView()); err != nil {
+			m.filterBar.SetError(err)
+			return m, nil
+		}
+		m.filters[m.filterView()] = msg.Text
+		m.filterBar.SetApplied(msg.Text)
+		m.filterBar.Blur()
+		m.focusArea = FocusMain
+		m.updateComponentSizes()
+		return m, cmd
+
+	case messages.FilterClosedMsg:
+		m.filterBar.Blur()
+		m.focusArea = FocusMain
+		m.updateComponentSizes()
+
 	case messages.TabSwitchedMsg:
-		if msg.Index == 0 {
-			m.currentView = messages.IssueView
+		m.currentView = tabViews[msg.Index]
+		m.openedProject = nil
+		m.projectIssues = nil
+
+		// Each list view keeps its own filter; the board shows the issues
+		// of the issue list, so it shares its filter
+		filter := m.filters[m.filterView()]
+		m.filterBar.SetApplied(filter)
+		if m.currentView == messages.BoardView {
+			m.board.SetIssues(m.issues)
+			if cursor := m.nextCursors[messages.IssueView]; cursor != "" && m.service != nil {
+				cmds = append(cmds, m.loadIssueListCmd(cursor))
+			}
 		} else {
-			m.currentView = messages.ProjectView
+			m.listView.SetView(m.currentView, m.itemsFor(m.currentView), m.nextCursors[m.currentView])
+			m.listView.SetError(m.loadErrors[m.currentView])
+			m.listView.SetSearch(searchText(filter))
 		}
+		m.updateComponentSizes()
 
 	case messages.ItemSelectedMsg:
+		// Notifications open the issue they are about
+		if notification, ok := msg.Item.(domain.Notification); ok {
+			if m.service != nil {
+				cmds = append(cmds, m.openNotification(notification))
+			}
+			break
+		}
+
+		// Cycles open the issue list filtered to the cycle
+		if cycle, ok := msg.Item.(domain.Cycle); ok {
+			cmds = append(cmds, m.openCycle(cycle))
+			break
+		}
+
+		// Projects open in place of the list of projects
+		if project, ok := msg.Item.(domain.Project); ok {
+			cmds = append(cmds, m.openProject(project))
+			break
+		}
+
 		m.detailPaneOpen = true
 		m.detailPane.SetItem(msg.Item)
 		m.focusArea = FocusDetailPane
+		m.updateComponentSizes()
+		if issue, ok := msg.Item.(domain.Issue); ok && m.service != nil {
+			cmds = append(cmds,
+				loadCommentsCmd(m.service, issue.LinearID),
+				loadHistoryCmd(m.service, issue.LinearID),
+				loadRelationsCmd(m.service, issue.LinearID),
+			)
+		}
+
+	case messages.OpenIssueMsg:
+		if m.service != nil {
+			cmds = append(cmds, loadIssueCmd(m.service, msg.IssueID))
+		}
+
+	case messages.CommentSubmittedMsg:
+		if m.service != nil {
+			cmds = append(cmds, createCommentCmd(m.service, msg.IssueID, msg.Body))
+		}
+
+	case messages.CommentCreatedMsg:
+		if msg.Err == nil && m.service != nil {
+			cmds = append(cmds, loadCommentsCmd(m.service, msg.IssueID))
+			m.recordComment(msg)
+		}
+
+	case messages.IssueFormTeamChangedMsg:
+		if m.service != nil {
+			cmds = append(cmds, loadTeamOptionsCmd(m.service, msg.TeamID))
+		}
+
+	case teamOptionsLoadedMsg:
+		m.issueForm.SetTeamOptions(msg.teamID, stateOptions(msg.states), labelOptions(msg.labels), msg.err)
+		return m, m.editOptionsLoaded(msg)
+
+	case messages.PickerClosedMsg:
+		cmds = append(cmds, m.finishEdit(msg), m.switchTeam(msg), m.snoozeNotification(msg), m.pickRelation(msg), m.linkIssue(msg), m.confirmBulkArchive(msg))
+
+	case cyclesLoadedMsg:
+		return m, m.editCyclesLoaded(msg)
+
+	case notificationUpdatedMsg:
+		cmds = append(cmds, m.notificationUpdated(msg))
+
+	case projectIssuesLoadedMsg:
+		// Issues of a project closed since they were requested are stale
+		if !m.projectShown(msg.projectID) {
+			return m, nil
+		}
+		return m.Update(msg.loaded)
+
+	case projectDetailsLoadedMsg:
+		if m.projectShown(msg.projectID) {
+			m.projectView.SetDetails(msg.details, msg.err)
+			m.updateComponentSizes()
+		}
+		return m, nil
+
+	case bulkItemDoneMsg:
+		return m, m.bulkItemDone(msg)
+
+	case issuesLinkedMsg:
+		cmds = append(cmds, m.issuesLinked(msg))
+
+	case issueLoadedMsg:
+		if msg.err != nil {
+			m.footer.SetError("Could not open issue: " + msg.err.Error())
+		} else {
+			issue := *msg.issue
+			cmds = append(cmds, func() tea.Msg { return messages.ItemSelectedMsg{Item: issue} })
+		}
+
+	case messages.EditorFinishedMsg:
+		cmds = append(cmds, m.finishTextEdit(msg))
+
+	case messages.CardMovedMsg:
+		if m.service != nil {
+			stateID := msg.StateID
+			cmds = append(cmds, m.updateIssue(msg.Issue, domain.IssueUpdate{StateID: &stateID}))
+		}
+
+	case messages.IssueUpdatedMsg:
+		if m.service != nil {
+			cmds = append(cmds, m.issueUpdated(msg))
+		}
+		if msg.Err == nil && m.service != nil && m.showsIssue(msg.IssueID) {
+			cmds = append(cmds, loadHistoryCmd(m.service, msg.IssueID))
+		}
+
+	case messages.IssueSubmittedMsg:
+		if m.service != nil {
+			cmds = append(cmds, createIssueCmd(m.service, msg.Issue))
+		}
+
+	case messages.IssueCreatedMsg:
+		if msg.Err == nil {
+			m.insertIssue(*msg.Issue)
+			if m.service != nil {
+				cmds = append(cmds, m.reloadRelations(msg.Issue.ParentID))
+				if !m.service.Queued(msg.Issue.LinearID) {
+					m.changes.record(change{kind: changeCreate, issue: *msg.Issue, summary: "creation of " + msg.Issue.ID})
+				}
+			}
+		}
+
+	case issueArchivedMsg:
+		if msg.err != nil {
+			return m, m.footer.Toast(fmt.Sprintf("Could not archive %s: %s", msg.issue.ID, explainError(msg.err)))
+		}
+		m.removeIssue(msg.issue.LinearID)
+		m.changes.record(change{kind: changeArchive, issue: msg.issue, summary: "archival of " + msg.issue.ID})
+		return m, m.footer.Toast("Archived " + msg.issue.ID + ", u to undo")
+
+	case changeDoneMsg:
+		return m, m.changeDone(msg)
 
 	case messages.CloseDetailPaneMsg:
 		m.detailPaneOpen = false
 		m.focusArea = FocusMain
+		m.updateComponentSizes()
 	}
 
 	// Update child components based on focus
@@ -108,15 +635,28 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case FocusTabs:
 		m.tabs.Focus()
 		m.listView.Blur()
+		m.board.Blur()
 		m.detailPane.Blur()
 	case FocusMain:
 		m.tabs.Blur()
-		m.listView.Focus()
+		if m.currentView == messages.BoardView {
+			m.listView.Blur()
+			m.board.Focus()
+		} else {
+			m.listView.Focus()
+			m.board.Blur()
+		}
 		m.detailPane.Blur()
 	case FocusDetailPane:
 		m.tabs.Blur()
 		m.listView.Blur()
+		m.board.Blur()
 		m.detailPane.Focus()
+	case FocusIssueForm:
+		m.tabs.Blur()
+		m.listView.Blur()
+		m.board.Blur()
+		m.detailPane.Blur()
 	}
 
 	// Update child components
@@ -127,33 +667,329 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	m.listView, cmd = m.listView.Update(msg)
 	cmds = append(cmds, cmd)
 
+	m.board, cmd = m.board.Update(msg)
+	cmds = append(cmds, cmd)
+
+	m.filterBar, cmd = m.filterBar.Update(msg)
+	cmds = append(cmds, cmd)
+
+	if m.issueForm.Active() {
+		m.issueForm, cmd = m.issueForm.Update(msg)
+		cmds = append(cmds, cmd)
+		if !m.issueForm.Active() && m.focusArea == FocusIssueForm {
+			m.focusArea = FocusMain
+		}
+	}
+
 	if m.detailPaneOpen {
 		m.detailPane, cmd = m.detailPane.Update(msg)
 		cmds = append(cmds, cmd)
 	}
 
+	if m.edit != nil && m.edit.picker != nil {
+		*m.edit.picker, cmd = m.edit.picker.Update(msg)
+		cmds = append(cmds, cmd)
+	}
+
+	if m.actionPicker != nil {
+		*m.actionPicker, cmd = m.actionPicker.Update(msg)
+		cmds = append(cmds, cmd)
+	}
+
 	m.footer, cmd = m.footer.Update(msg)
 	cmds = append(cmds, cmd)
 
 	return m, tea.Batch(cmds...)
 }
 
+// handleBlockingKey handles key presses while the loading or error screen
+// is shown
+func (m Model) handleBlockingKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "q", "ctrl+c":
+		return m, tea.Quit
+	case "r":
+		if m.err == nil {
+			return m, nil
+		}
+		m.err = nil
+		m.loading = true
+		if m.service == nil {
+			return m, tea.Batch(m.spinner.Tick, initServiceCmd(m.cfg))
+		}
+		return m, tea.Batch(m.spinner.Tick, m.loadIssueListCmd(""), loadProjectsCmd(m.service, ""))
+	}
+	return m, nil
+}
+
+// loadFailed reports a page of a view that could not be loaded. A first
+// page is shown failed in the view, or in the footer if the view still
+// has rows from an earlier load; a further page in the footer, keeping the
+// rows already loaded.
+func (m *Model) loadFailed(msg messages.DataLoadedMsg) {
+	if msg.Append {
+		m.footer.SetError("Could not load more: " + explainError(msg.Err))
+		return
+	}
+	m.loadErrors[msg.View] = msg.Err
+
+	// Cycles are loaded whole for the teams shown, so those of an earlier
+	// load, perhaps of other teams, are not kept
+	if msg.View == messages.CycleView {
+		m.cycles = nil
+		if m.currentView == messages.CycleView {
+			m.listView.SetView(messages.CycleView, nil, "")
+		}
+		return
+	}
+	if msg.View == m.currentView && len(m.itemsFor(msg.View)) > 0 {
+		m.footer.SetError("Could not refresh: " + explainError(msg.Err))
+	}
+}
+
+// applyQuery compiles a filter query and reloads the issue list with it.
+// Compile errors are shown in the filter bar, in which case it returns nil.
+func (m *Model) applyQuery(q query.Query) tea.Cmd {
+	if m.service == nil {
+		return nil
+	}
+
+	resolver := workspaceResolver{teams: m.service.GetTeams(), users: m.service.GetUsers(), projects: m.projects}
+	compiled, err := query.Compile(q, m.service.DefaultIssueFilter(), resolver, time.Now())
+	if err != nil {
+		m.filterBar.SetError(err)
+		return nil
+	}
+
+	m.issueQuery = compiled
+	m.generations[messages.IssueView]++
+	m.issues = nil
+	delete(m.nextCursors, messages.IssueView)
+	m.board.SetIssues(nil)
+	if m.currentView == messages.IssueView {
+		m.listView.SetView(messages.IssueView, nil, "")
+	}
+	return m.loadIssueListCmd("")
+}
+
+// loadIssueListCmd fetches the page of the issue list after cursor, tagged
+// with the generation of its query
+func (m Model) loadIssueListCmd(cursor string) tea.Cmd {
+	return tagGeneration(loadIssuesCmd(m.service, messages.IssueView, m.issueQuery, cursor), m.generations[messages.IssueView])
+}
+
+// queryFor returns the query of an issue list view. Only the issue list
+// can be filtered by a query; the other views are searched by text.
+func (m Model) queryFor(view messages.ViewType) query.Compiled {
+	switch view {
+	case messages.MyIssuesView:
+		return query.Compiled{Filter: m.service.MyIssuesFilter()}
+	case messages.CreatedView:
+		return query.Compiled{Filter: m.service.CreatedIssuesFilter()}
+	case messages.ProjectIssuesView:
+		if m.openedProject != nil {
+			return projectQuery(m.openedProject.ID)
+		}
+	}
+	return m.issueQuery
+}
+
+// filterView returns the view whose filter applies to the current view
+func (m Model) filterView() messages.ViewType {
+	if m.currentView == messages.BoardView {
+		return messages.IssueView
+	}
+	return m.currentView
+}
+
+// openIssueForm opens the form for a new issue in the default team, or for
+// a sub-issue of parent in its team if parent is not nil
+func (m *Model) openIssueForm(parent *domain.Issue) tea.Cmd {
+	options := issueform.Options{
+		Teams:      teamOptions(m.service.GetTeams()),
+		Assignees:  userOptions(m.service.GetUsers()),
+		Projects:   projectOptions(m.projects),
+		Priorities: priorityOptions(),
+	}
+	if team := m.service.GetDefaultTeam(); team != nil {
+		options.TeamID = team.ID
+	}
+	if parent != nil {
+		options.ParentID, options.Parent = parent.LinearID, parent.ID
+		if parent.TeamID != "" {
+			options.TeamID = parent.TeamID
+		}
+	}
+
+	m.focusArea = FocusIssueForm
+	m.listView.Blur()
+	m.updateComponentSizes()
+	return m.issueForm.Open(options)
+}
+
+// showsIssue reports whether the issue with a Linear ID is open in the
+// detail pane
+func (m Model) showsIssue(issueID string) bool {
+	issue, ok := m.detailPane.Item().(domain.Issue)
+	return ok && m.detailPaneOpen && issue.LinearID == issueID
+}
+
+// itemsFor returns the loaded items for a view
+func (m Model) itemsFor(view messages.ViewType) []interface{} {
+	var items []interface{}
+	switch view {
+	case messages.IssueView:
+		for _, issue := range m.issues {
+			items = append(items, issue)
+		}
+	case messages.MyIssuesView:
+		for _, issue := range m.myIssues {
+			items = append(items, issue)
+		}
+	case messages.CreatedView:
+		for _, issue := range m.createdIssues {
+			items = append(items, issue)
+		}
+	case messages.ProjectIssuesView:
+		for _, issue := range m.projectIssues {
+			items = append(items, issue)
+		}
+	case messages.ProjectView:
+		for _, project := range m.projects {
+			items = append(items, project)
+		}
+	case messages.InboxView:
+		for _, notification := range m.notifications {
+			items = append(items, notification)
+		}
+	case messages.CycleView:
+		for _, cycle := range m.cycles {
+			items = append(items, cycle)
+		}
+	}
+	return items
+}
+
+// mentionNames maps the handles users are @mentioned by, their display
+// name and the part of their email before the @, to their names
+func mentionNames(users []linear.User) map[string]string {
+	names := make(map[string]string, 2*len(users))
+	for _, user := range users {
+		handle, _, _ := strings.Cut(user.Email, "@")
+		for _, key := range []string{user.DisplayName, handle} {
+			if key != "" {
+				names[strings.ToLower(key)] = user.Name
+			}
+		}
+	}
+	return names
+}
+
+func issuesFromItems(items []interface{}) []domain.Issue {
+	issues := make([]domain.Issue, 0, len(items))
+	for _, item := range items {
+		if issue, ok := item.(domain.Issue); ok {
+			issues = append(issues, issue)
+		}
+	}
+	return issues
+}
+
+func notificationsFromItems(items []interface{}) []domain.Notification {
+	notifications := make([]domain.Notification, 0, len(items))
+	for _, item := range items {
+		if notification, ok := item.(domain.Notification); ok {
+			notifications = append(notifications, notification)
+		}
+	}
+	return notifications
+}
+
+func cyclesFromItems(items []interface{}) []domain.Cycle {
+	cycles := make([]domain.Cycle, 0, len(items))
+	for _, item := range items {
+		if cycle, ok := item.(domain.Cycle); ok {
+			cycles = append(cycles, cycle)
+		}
+	}
+	return cycles
+}
+
+func projectsFromItems(items []interface{}) []domain.Project {
+	projects := make([]domain.Project, 0, len(items))
+	for _, item := range items {
+		if project, ok := item.(domain.Project); ok {
+			projects = append(projects, project)
+		}
+	}
+	return projects
+}
+
+// appendIssues appends a further page of issues, skipping any already
+// loaded since pages can shift while issues are being updated
+func appendIssues(existing, page []domain.Issue) []domain.Issue {
+	seen := make(map[string]bool, len(existing))
+	for _, issue := range existing {
+		seen[issue.LinearID] = true
+	}
+
+	merged := append([]domain.Issue{}, existing...)
+	for _, issue := range page {
+		if !seen[issue.LinearID] {
+			merged = append(merged, issue)
+		}
+	}
+	return merged
+}
+
+// appendProjects appends a further page of projects, skipping any already
+// loaded
+func appendProjects(existing, page []domain.Project) []domain.Project {
+	seen := make(map[string]bool, len(existing))
+	for _, project := range existing {
+		seen[project.ID] = true
+	}
+
+	merged := append([]domain.Project{}, existing...)
+	for _, project := range page {
+		if !seen[project.ID] {
+			merged = append(merged, project)
+		}
+	}
+	return merged
+}
+
 func (m *Model) updateComponentSizes() {
 	tabHeight := 1
 	footerHeight := 1
-	contentHeight := m.height - tabHeight - footerHeight
+	contentHeight := m.height - tabHeight - footerHeight - m.filterBar.Height()
 
-	// Update listview size
+	// Update listview and board size. An opened project's details take
+	// their height from the list of its issues.
+	listWidth := m.width
 	if m.detailPaneOpen {
-		listWidth := m.width * 2 / 3
-		m.listView.SetSize(listWidth, contentHeight)
+		listWidth = m.width * 2 / 3
 		m.detailPane.SetSize(m.width-listWidth, contentHeight)
-	} else {
-		m.listView.SetSize(m.width, contentHeight)
 	}
+	listHeight := contentHeight
+	if m.currentView == messages.ProjectIssuesView {
+		m.projectView.SetWidth(listWidth)
+		listHeight = max(contentHeight-m.projectView.Height(), 1)
+	}
+	m.listView.SetSize(listWidth, listHeight)
+	m.board.SetSize(listWidth, contentHeight)
 
-	// Update footer width
+	// Update footer and filter bar width
 	m.footer.SetWidth(m.width)
+	m.filterBar.SetWidth(m.width)
+
+	m.issueForm.SetSize(min(70, m.width-4), m.height-2)
+	if m.edit != nil && m.edit.picker != nil {
+		m.edit.picker.SetSize(m.pickerSize())
+	}
+	if m.actionPicker != nil {
+		m.actionPicker.SetSize(m.pickerSize())
+	}
 }
 
 func (m Model) View() string {
@@ -161,8 +997,35 @@ func (m Model) View() string {
 		return "Loading..."
 	}
 
+	if m.err != nil {
+		return m.errorView()
+	}
+
+	if m.loading {
+		return m.loadingView()
+	}
+
+	if m.issueForm.Active() {
+		return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, m.issueForm.View())
+	}
+	if m.edit != nil && m.edit.picker != nil {
+		return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, m.edit.picker.View())
+	}
+	if m.actionPicker != nil {
+		return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, m.actionPicker.View())
+	}
+	if m.bulkReport != nil {
+		return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, m.bulkReportView())
+	}
+
 	tabBar := m.tabs.View()
 	mainContent := m.listView.View()
+	if m.currentView == messages.BoardView {
+		mainContent = m.board.View()
+	}
+	if m.currentView == messages.ProjectIssuesView {
+		mainContent = lipgloss.JoinVertical(lipgloss.Left, m.projectView.View(), mainContent)
+	}
 
 	var content string
 	if m.detailPaneOpen {
@@ -176,8 +1039,72 @@ func (m Model) View() string {
 		content = mainContent
 	}
 
-	footer := m.footer.View()
+	footerModel := m.footer
+	if m.currentView.ListsIssues() {
+		footerModel.SetMarked(m.listView.MarkedCount())
+	}
+	footer := footerModel.View()
 
+	if m.filterBar.Active() {
+		return lipgloss.JoinVertical(lipgloss.Left, tabBar, m.filterBar.View(), content, footer)
+	}
 	return lipgloss.JoinVertical(lipgloss.Left, tabBar, content, footer)
 
 }
+
+func (m Model) loadingView() string {
+	message := m.spinner.View() + " Loading your Linear workspace..."
+	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, message)
+}
+
+func (m Model) errorView() string {
+	title, hint := describeError(m.err)
+
+	body := lipgloss.JoinVertical(lipgloss.Left,
+		m.styles.StatusHigh.Render(title),
+		"",
+		lipgloss.NewStyle().Width(min(m.width-4, 80)).Render(m.err.Error()),
+		"",
+		m.styles.DetailMeta.Render(hint),
+		m.styles.DetailMeta.Render("r: retry | q: quit"),
+	)
+
+	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, body)
+}
+
+// describeError returns a short title and a hint for an error shown on the
+// error screen
+func describeError(err error) (string, string) {
+	var linearErr *linear.LinearError
+	if errors.As(err, &linearErr) {
+		switch linearErr.Type {
+		case linear.ErrorTypeAuth:
+			return "Authentication failed", "Check that LINEAR_API_KEY holds a valid Linear API key."
+		case linear.ErrorTypeNetwork:
+			return "Unable to reach Linear", "Check your network connection and try again."
+		case linear.ErrorTypeRateLimit:
+			return "Rate limit exceeded", "Wait a moment before retrying."
+		}
+	}
+	return "Something went wrong", "Run with DEBUG=1 and check debug.log for details."
+}
+
+// explainError says in a few words why a request to Linear failed, for
+// errors shown in a toast
+func explainError(err error) string {
+	var linearErr *linear.LinearError
+	if !errors.As(err, &linearErr) {
+		return err.Error()
+	}
+	switch linearErr.Type {
+	case linear.ErrorTypeNetwork:
+		return "Linear could not be reached"
+	case linear.ErrorTypeAuth:
+		return "Linear did not accept the API key"
+	case linear.ErrorTypeRateLimit:
+		return "too many requests to Linear, wait a moment before retrying"
+	case linear.ErrorTypeValidation:
+		return "the change is not valid: " + linearErr.Message
+	}
+	return "Linear refused the change: " + linearErr.Message
+}
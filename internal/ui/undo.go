@@ -0,0 +1,451 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/linear-tui/linear-tui/internal/domain"
+	"github.com/linear-tui/linear-tui/internal/services"
+	"github.com/linear-tui/linear-tui/internal/ui/messages"
+)
+
+// maxUndo is the number of changes that can be undone
+const maxUndo = 50
+
+// changeKind is a kind of change that can be undone
+type changeKind int
+
+const (
+	changeUpdate changeKind = iota
+	changeComment
+	changeCreate
+	changeArchive
+	changeBulk
+)
+
+// change is a change made to an issue that can be undone and redone
+type change struct {
+	kind  changeKind
+	issue domain.Issue
+
+	// before and after are the fields of an update as they were before
+	// it and as it set them
+	before domain.IssueUpdate
+	after  domain.IssueUpdate
+
+	// commentID is the ID of a comment posted, which changes each time it
+	// is posted again by a redo, and body its text
+	commentID string
+	body      string
+
+	// parts are the updates or archivals of each issue of a bulk action,
+	// undone and redone together
+	parts []change
+
+	// summary describes the change, such as "ENG-12 status Todo → Done"
+	summary string
+}
+
+// undoOp is what an update or mutation in flight does to the undo history
+type undoOp int
+
+const (
+	opRecord undoOp = iota // A new change, recorded once it succeeds
+	opUndo
+	opRedo
+)
+
+// undoHistory holds the changes that can be undone, most recent last, and
+// those undone that can be redone
+type undoHistory struct {
+	done   []change
+	undone []change
+
+	// updates maps the updates in flight, by the number the service gave
+	// them, to the change they make
+	updates map[int]pendingChange
+
+	// busy is set while an undo or redo is in flight
+	busy bool
+
+	// bulk is the bulk change being undone or redone, if any
+	bulk *bulkUndo
+}
+
+// bulkUndo is the undo or redo of a bulk change in flight. err is the last
+// error a part of it failed with.
+type bulkUndo struct {
+	change    change
+	op        undoOp
+	remaining int
+	failed    int
+	err       error
+}
+
+type pendingChange struct {
+	change change
+	op     undoOp
+}
+
+func newUndoHistory() undoHistory {
+	return undoHistory{updates: make(map[int]pendingChange)}
+}
+
+// changeDoneMsg is sent when undoing or redoing a change other than an
+// update has finished. issue is set for an issue restored, and commentID
+// for a comment posted again, with queued set if it was queued offline.
+type changeDoneMsg struct {
+	change    change
+	op        undoOp
+	issue     *domain.Issue
+	commentID string
+	queued    bool
+	err       error
+}
+
+// record adds a change to the history. A new change cannot be followed by
+// the changes undone before it, so they are dropped.
+func (h *undoHistory) record(c change) {
+	h.done = append(h.done, c)
+	if len(h.done) > maxUndo {
+		h.done = h.done[len(h.done)-maxUndo:]
+	}
+	h.undone = nil
+}
+
+// undo undoes the last change, or redoes the last change undone
+func (m *Model) undo(op undoOp) tea.Cmd {
+	if m.changes.busy {
+		m.footer.SetError("Wait for the last undo to finish")
+		return nil
+	}
+
+	stack := &m.changes.done
+	if op == opRedo {
+		stack = &m.changes.undone
+	}
+	if len(*stack) == 0 {
+		if op == opRedo {
+			m.footer.SetError("Nothing to redo")
+		} else {
+			m.footer.SetError("Nothing to undo")
+		}
+		return nil
+	}
+	c := (*stack)[len(*stack)-1]
+	*stack = (*stack)[:len(*stack)-1]
+	m.changes.busy = true
+
+	switch c.kind {
+	case changeUpdate:
+		return m.undoUpdate(c, op)
+	case changeBulk:
+		return m.undoBulk(c, op)
+	}
+	return changeCmd(m.service, c, op)
+}
+
+// undoUpdate undoes or redoes an update, showing the fields it sets at once
+func (m *Model) undoUpdate(c change, op undoOp) tea.Cmd {
+	update := c.before
+	if op == opRedo {
+		update = c.after
+	}
+	issue := c.issue
+	if current, ok := m.issueByID(issue.LinearID); ok {
+		issue = current
+	}
+	shown, seq := m.service.BeginUpdate(issue, update)
+	m.showIssue(shown)
+	m.changes.updates[seq] = pendingChange{change: c, op: op}
+	return updateIssueCmd(m.service, issue.LinearID, seq, update)
+}
+
+// undoBulk undoes or redoes each part of a bulk change, bulkConcurrency of
+// them at a time. The change moves once every part has finished.
+func (m *Model) undoBulk(c change, op undoOp) tea.Cmd {
+	m.changes.bulk = &bulkUndo{change: c, op: op, remaining: len(c.parts)}
+	sem := make(chan struct{}, bulkConcurrency)
+	cmds := make([]tea.Cmd, len(c.parts))
+	for i, part := range c.parts {
+		if part.kind == changeUpdate {
+			cmds[i] = throttle(sem, m.undoUpdate(part, op))
+		} else {
+			cmds[i] = throttle(sem, changeCmd(m.service, part, op))
+		}
+	}
+	return tea.Batch(cmds...)
+}
+
+// partFinished counts a part of a bulk change undone or redone. Once all
+// have finished, the change moves as a whole if every part succeeded;
+// otherwise it stays where it was, to be tried again on all its issues.
+func (m *Model) partFinished(err error) tea.Cmd {
+	b := m.changes.bulk
+	b.remaining--
+	if err != nil {
+		b.failed++
+		b.err = err
+	}
+	if b.remaining > 0 {
+		return nil
+	}
+	m.changes.bulk = nil
+	if b.failed > 0 {
+		err = fmt.Errorf("failed on %s: %w", pluralIssues(b.failed), b.err)
+	}
+	return m.changeFinished(b.change, b.op, err)
+}
+
+// changeCmd undoes or redoes a change other than an update
+func changeCmd(service *services.LinearService, c change, op undoOp) tea.Cmd {
+	return func() tea.Msg {
+		msg := changeDoneMsg{change: c, op: op}
+		issueID := c.issue.LinearID
+		switch {
+		case c.kind == changeComment && op == opUndo:
+			msg.err = service.DeleteComment(c.commentID)
+		case c.kind == changeComment:
+			var comment *domain.Comment
+			if comment, msg.err = service.CreateComment(issueID, c.body); msg.err == nil {
+				msg.commentID, msg.queued = comment.ID, comment.Queued
+			}
+		case c.kind == changeCreate && op == opUndo:
+			msg.err = service.DeleteTicket(issueID)
+		case c.kind == changeArchive && op == opRedo:
+			msg.err = service.ArchiveTicket(issueID)
+		default:
+			// Undoing an archival and redoing a creation both restore
+			msg.issue, msg.err = service.UnarchiveTicket(issueID)
+		}
+		return msg
+	}
+}
+
+// updateFinished records an update once it has succeeded, or finishes
+// undoing or redoing one, on its own or as part of a bulk change
+func (m *Model) updateFinished(msg messages.IssueUpdatedMsg, shown domain.Issue) tea.Cmd {
+	pending, ok := m.changes.updates[msg.Seq]
+	if !ok {
+		return nil
+	}
+	delete(m.changes.updates, msg.Seq)
+
+	if pending.op == opRecord {
+		if msg.Err == nil {
+			c := pending.change
+			c.summary = describeUpdate(c.issue, shown, c.after)
+			m.changes.record(c)
+		}
+		return nil
+	}
+	if m.changes.bulk != nil {
+		return m.partFinished(msg.Err)
+	}
+	return m.changeFinished(pending.change, pending.op, msg.Err)
+}
+
+// changeDone finishes undoing or redoing a change other than an update, on
+// its own or as part of a bulk change
+func (m *Model) changeDone(msg changeDoneMsg) tea.Cmd {
+	c := msg.change
+	if msg.err == nil {
+		restored := (c.kind == changeCreate && msg.op == opRedo) || (c.kind == changeArchive && msg.op == opUndo)
+		switch {
+		case c.kind == changeComment:
+			if msg.op == opRedo {
+				c.commentID = msg.commentID
+			}
+		case restored && msg.issue != nil:
+			m.insertIssue(*msg.issue)
+		case !restored:
+			m.removeIssue(c.issue.LinearID)
+		}
+	}
+
+	var cmd tea.Cmd
+	if msg.queued {
+		// A comment queued offline cannot be deleted until it is sent, so
+		// it is not recorded again, as a new one would not be
+		m.changes.busy = false
+		cmd = m.footer.Toast("Redid " + c.summary + ", queued until online, so it cannot be undone")
+	} else if m.changes.bulk != nil {
+		cmd = m.partFinished(msg.err)
+	} else {
+		cmd = m.changeFinished(c, msg.op, msg.err)
+	}
+	if c.kind == changeComment && msg.err == nil && m.showsIssue(c.issue.LinearID) {
+		return tea.Batch(cmd, loadCommentsCmd(m.service, c.issue.LinearID))
+	}
+	return cmd
+}
+
+// changeFinished moves a change undone to the changes that can be redone,
+// or the other way round, and says what was reverted. A change that could
+// not be undone or redone stays where it was.
+func (m *Model) changeFinished(c change, op undoOp, err error) tea.Cmd {
+	m.changes.busy = false
+
+	action, done := "undo", "Undid"
+	from, to := &m.changes.done, &m.changes.undone
+	if op == opRedo {
+		action, done = "redo", "Redid"
+		from, to = to, from
+	}
+	if err != nil {
+		*from = append(*from, c)
+		return m.footer.Toast(fmt.Sprintf("Could not %s %s: %s", action, c.summary, explainError(err)))
+	}
+	*to = append(*to, c)
+	return m.footer.Toast(done + " " + c.summary)
+}
+
+// recordUpdate notes an update about to be sent, to be recorded once it
+// succeeds
+func (m *Model) recordUpdate(seq int, issue domain.Issue, update domain.IssueUpdate) {
+	m.changes.updates[seq] = pendingChange{
+		change: change{kind: changeUpdate, issue: issue, before: inverseUpdate(issue, update), after: update},
+		op:     opRecord,
+	}
+}
+
+// inverseUpdate returns the update that sets the fields update changes
+// back to their values in issue
+func inverseUpdate(issue domain.Issue, update domain.IssueUpdate) domain.IssueUpdate {
+	var inverse domain.IssueUpdate
+	if update.Title != nil {
+		inverse.Title = &issue.Title
+	}
+	if update.Description != nil {
+		inverse.Description = &issue.Description
+	}
+	if update.StateID != nil {
+		inverse.StateID = &issue.StateID
+	}
+	if update.Priority != nil {
+		inverse.Priority = &issue.PriorityNumber
+	}
+	if update.AssigneeID != nil {
+		inverse.AssigneeID = &issue.AssigneeID
+	}
+	if update.ProjectID != nil {
+		inverse.ProjectID = &issue.ProjectID
+	}
+	if update.CycleID != nil {
+		inverse.CycleID = &issue.CycleID
+	}
+	if update.LabelIDs != nil {
+		inverse.LabelIDs = labelIDs(issue.Labels)
+	}
+	return inverse
+}
+
+// describeUpdate describes an update of an issue from before to after
+func describeUpdate(before, after domain.Issue, update domain.IssueUpdate) string {
+	orNone := func(value, none string) string {
+		if value == "" {
+			return none
+		}
+		return value
+	}
+
+	var parts []string
+	if update.StateID != nil {
+		parts = append(parts, fmt.Sprintf("status %s → %s", before.Status, after.Status))
+	}
+	if update.Priority != nil {
+		parts = append(parts, fmt.Sprintf("priority %s → %s", before.Priority, after.Priority))
+	}
+	if update.AssigneeID != nil {
+		parts = append(parts, fmt.Sprintf("assignee %s → %s", before.Assignee, after.Assignee))
+	}
+	if update.ProjectID != nil {
+		parts = append(parts, fmt.Sprintf("project %s → %s", orNone(before.Project, "none"), orNone(after.Project, "none")))
+	}
+	if update.CycleID != nil {
+		parts = append(parts, fmt.Sprintf("cycle %s → %s", orNone(before.Cycle, "none"), orNone(after.Cycle, "none")))
+	}
+	if update.LabelIDs != nil {
+		parts = append(parts, "labels")
+	}
+	if update.Title != nil {
+		parts = append(parts, "title")
+	}
+	if update.Description != nil {
+		parts = append(parts, "description")
+	}
+	return before.ID + " " + strings.Join(parts, ", ")
+}
+
+// issueByID returns the loaded copy of an issue, from the lists or the
+// detail pane
+func (m Model) issueByID(issueID string) (domain.Issue, bool) {
+	for _, issues := range [][]domain.Issue{m.issues, m.myIssues, m.createdIssues, m.projectIssues} {
+		for _, issue := range issues {
+			if issue.LinearID == issueID {
+				return issue, true
+			}
+		}
+	}
+	if issue, ok := m.detailPane.Item().(domain.Issue); ok && issue.LinearID == issueID {
+		return issue, true
+	}
+	return domain.Issue{}, false
+}
+
+// recordComment records a comment posted. Comments queued offline cannot
+// be deleted until they are sent, so they are not recorded.
+func (m *Model) recordComment(msg messages.CommentCreatedMsg) {
+	if msg.Comment == nil || msg.Comment.Queued {
+		return
+	}
+	issue, ok := m.issueByID(msg.IssueID)
+	if !ok {
+		issue = domain.Issue{ID: "an issue", LinearID: msg.IssueID}
+	}
+	m.changes.record(change{
+		kind:      changeComment,
+		issue:     issue,
+		commentID: msg.Comment.ID,
+		body:      msg.Comment.Body,
+		summary:   "comment on " + issue.ID,
+	})
+}
+
+// insertIssue adds an issue created or restored to the top of the issue
+// list and to the board
+func (m *Model) insertIssue(issue domain.Issue) {
+	m.issues = append([]domain.Issue{issue}, m.issues...)
+	m.board.SetIssues(m.issues)
+	if m.currentView == messages.IssueView {
+		m.listView.InsertItem(issue)
+	}
+}
+
+// removeIssue drops an issue archived or deleted from the lists and the
+// board, closing the detail pane if it shows the issue
+func (m *Model) removeIssue(issueID string) {
+	remove := func(issues []domain.Issue) []domain.Issue {
+		var kept []domain.Issue
+		for _, issue := range issues {
+			if issue.LinearID != issueID {
+				kept = append(kept, issue)
+			}
+		}
+		return kept
+	}
+	m.issues = remove(m.issues)
+	m.myIssues = remove(m.myIssues)
+	m.createdIssues = remove(m.createdIssues)
+	m.projectIssues = remove(m.projectIssues)
+	m.board.SetIssues(m.issues)
+	if m.currentView.ListsIssues() {
+		m.listView.RemoveItem(domain.Issue{LinearID: issueID})
+	}
+	if m.showsIssue(issueID) {
+		m.detailPaneOpen = false
+		m.focusArea = FocusMain
+		m.updateComponentSizes()
+	}
+}